@@ -0,0 +1,250 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"mytrader.github.com/orderbook"
+)
+
+// envPrefix is the prefix of the environment variables which override the flags, e.g. MYTRADER_LISTEN_ADDR
+const envPrefix = "MYTRADER_"
+
+// bookFlags are the settings of each orderbook, they are the flags of the defaults of the orderbooks and the keys of
+// each book in the config file
+type bookFlags struct {
+	cleanOrderFreq int64
+	maxQueueSize   int
+	orderExpired   int64
+	sessionEnd     string
+	priceScale     int
+	qtyScale       int
+	tickSize       string
+	lotSize        string
+	minQty         string
+	maxQty         string
+	stp            string
+	slippage       int
+	baseAsset      string
+	quoteAsset     string
+}
+
+// register registers the flags of the book to the flag set with their defaults
+func (b *bookFlags) register(fs *flag.FlagSet) {
+	fs.Int64Var(&b.cleanOrderFreq, "clean_order_freq", 10, "freq. of auto clean order in second")
+	fs.IntVar(&b.maxQueueSize, "max_queue_size", orderbook.DefaultMaxQueueSize, "max. size of queue")
+	fs.Int64Var(&b.orderExpired, "order_expired", int64(orderbook.DefaultOrderExpiration/time.Second), "expiration of the order, this is used by auto cleaner")
+	fs.StringVar(&b.sessionEnd, "session_end", "00:00", "end of the trading session (UTC) in HH:MM, the day orders are expired at that time")
+	fs.IntVar(&b.priceScale, "price_scale", 0, "number of the decimal places of the prices, the prices on the wire are in the smallest units of it")
+	fs.IntVar(&b.qtyScale, "quantity_scale", 0, "number of the decimal places of the quantities, the quantities on the wire are in the smallest units of it")
+	fs.StringVar(&b.tickSize, "tick_size", "", "decimal min. change of the price, the smallest unit of the price scale if it is empty")
+	fs.StringVar(&b.lotSize, "lot_size", "", "decimal min. change of the quantity, the smallest unit of the quantity scale if it is empty")
+	fs.StringVar(&b.minQty, "min_quantity", "", "decimal min. quantity of the order, the lot size if it is empty")
+	fs.StringVar(&b.maxQty, "max_quantity", "0", "decimal max. quantity of the order, unlimited if it is 0")
+	fs.StringVar(&b.stp, "stp", "none", "default self-trade prevention mode of the orderbooks [none|cancel_newest|cancel_oldest|cancel_both|decrement_cancel]")
+	fs.IntVar(&b.slippage, "market_protection", 0, "default max. slippage of the market orders from the best opposite price in basis points, the market orders are not protected if it is 0")
+	fs.StringVar(&b.baseAsset, "base_asset", "BASE", "base asset of the instrument which is bought or sold by the orders")
+	fs.StringVar(&b.quoteAsset, "quote_asset", "QUOTE", "quote asset of the instrument which pays for the base asset")
+}
+
+// options returns the options of the orderbook by the settings
+func (b *bookFlags) options() ([]orderbook.Option, error) {
+	se, err := time.Parse("15:04", b.sessionEnd)
+	if err != nil {
+		return nil, err
+	}
+	inst, err := parseInstrument(b.priceScale, b.qtyScale, b.tickSize, b.lotSize, b.minQty, b.maxQty)
+	if err != nil {
+		return nil, err
+	}
+	inst.Base, inst.Quote = b.baseAsset, b.quoteAsset
+	mode, err := orderbook.ParseSTP(b.stp)
+	if err != nil {
+		return nil, err
+	}
+
+	return []orderbook.Option{
+		orderbook.WithCleanTimeFrequecy(time.Duration(b.cleanOrderFreq) * time.Second),
+		orderbook.WithMaxQueueSize(b.maxQueueSize),
+		orderbook.WithOrderExpiration(time.Duration(b.orderExpired) * time.Second),
+		orderbook.WithSessionEnd(time.Duration(se.Hour())*time.Hour + time.Duration(se.Minute())*time.Minute),
+		orderbook.WithInstrumentSpec(inst),
+		orderbook.WithSelfTradePrevention(mode),
+		orderbook.WithMarketProtection(b.slippage),
+	}, nil
+}
+
+// config is the config file of the server, its keys are the names of the flags, and books are the settings of each
+// orderbook by the keys of bookFlags and its symbol
+//
+//	listen_addr: localhost:9999
+//	max_queue_size: 1000
+//	books:
+//	  - symbol: BTCUSD
+//	    price_scale: 2
+//	  - symbol: ETHUSD
+type config struct {
+	values map[string]interface{}
+	books  []map[string]interface{}
+}
+
+// loadConfig reads the yaml config file
+func loadConfig(path string) (*config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	cfg := &config{values: values}
+	if books, exist := values["books"]; exist {
+		delete(values, "books")
+		list, ok := books.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: books should be a list", path)
+		}
+		for i, book := range list {
+			m, ok := book.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: book %d should be a map", path, i+1)
+			}
+			if symbol, _ := m["symbol"].(string); len(symbol) == 0 {
+				return nil, fmt.Errorf("%s: the symbol of book %d is empty", path, i+1)
+			}
+			cfg.books = append(cfg.books, m)
+		}
+	}
+	return cfg, nil
+}
+
+// symbols returns the symbols of the books in the order of the file
+func (c *config) symbols() []string {
+	symbols := make([]string, 0, len(c.books))
+	for _, book := range c.books {
+		symbols = append(symbols, book["symbol"].(string))
+	}
+	return symbols
+}
+
+// book returns the settings of the book of the symbol, nil if the symbol is not in the file
+func (c *config) book(symbol string) map[string]interface{} {
+	for _, book := range c.books {
+		if book["symbol"] == symbol {
+			return book
+		}
+	}
+	return nil
+}
+
+// bookSymbols returns the symbols of the orderbooks, they are the books of the config file unless the symbols are
+// set by the file, the environment variables or the command line
+func bookSymbols(symbols string, cfg *config, overridden map[string]bool) []string {
+	if cfg != nil && len(cfg.books) > 0 && !overridden["symbols"] {
+		if _, exist := cfg.values["symbols"]; !exist {
+			return cfg.symbols()
+		}
+	}
+	list := strings.Split(symbols, ",")
+	for i := range list {
+		list[i] = strings.TrimSpace(list[i])
+	}
+	return list
+}
+
+// configValue converts the scalar value of the config file to the value of the flag
+func configValue(key string, v interface{}) (string, error) {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}, nil:
+		return "", fmt.Errorf("the value of %s should be a scalar", key)
+	}
+	return fmt.Sprint(v), nil
+}
+
+// applyOverrides sets the flags of the flag set by the environment variables and then the config file, the flags
+// which are set by the command line are not changed and the environment variables override the config file. It
+// returns the names of the flags which are set by the command line or the environment variables, they override
+// the settings of the books in the config file too.
+func applyOverrides(fs *flag.FlagSet, cfg *config) (map[string]bool, error) {
+	overridden := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { overridden[f.Name] = true })
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || overridden[f.Name] {
+			return
+		}
+		name := envPrefix + strings.ToUpper(f.Name)
+		if v, exist := os.LookupEnv(name); exist {
+			if err = fs.Set(f.Name, v); err != nil {
+				err = fmt.Errorf("%s: %w", name, err)
+			}
+			overridden[f.Name] = true
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return overridden, nil
+	}
+
+	for key, v := range cfg.values {
+		if fs.Lookup(key) == nil {
+			return nil, fmt.Errorf("unknown key %q of the config file", key)
+		}
+		if overridden[key] {
+			continue
+		}
+		s, err := configValue(key, v)
+		if err != nil {
+			return nil, err
+		}
+		if err := fs.Set(key, s); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return overridden, nil
+}
+
+// bookSettings returns the settings of the book of the symbol, they are the defaults of the flag set which are
+// overridden by the book in the config file, except the ones which are overridden by the command line or the
+// environment variables
+func bookSettings(fs *flag.FlagSet, cfg *config, overridden map[string]bool, symbol string) (*bookFlags, error) {
+	b := &bookFlags{}
+	bfs := flag.NewFlagSet(symbol, flag.ContinueOnError)
+	b.register(bfs)
+
+	var err error
+	bfs.VisitAll(func(f *flag.Flag) {
+		if err == nil {
+			err = bfs.Set(f.Name, fs.Lookup(f.Name).Value.String())
+		}
+	})
+	if err != nil || cfg == nil {
+		return b, err
+	}
+
+	for key, v := range cfg.book(symbol) {
+		if key == "symbol" || overridden[key] {
+			continue
+		}
+		if bfs.Lookup(key) == nil {
+			return nil, fmt.Errorf("unknown key %q of the book %s", key, symbol)
+		}
+		s, err := configValue(key, v)
+		if err != nil {
+			return nil, err
+		}
+		if err := bfs.Set(key, s); err != nil {
+			return nil, fmt.Errorf("%s of the book %s: %w", key, symbol, err)
+		}
+	}
+	return b, nil
+}
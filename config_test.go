@@ -0,0 +1,164 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newTestFlags returns the flag set of the server flags which are used by the tests and the defaults of the books
+func newTestFlags() *flag.FlagSet {
+	fs := flag.NewFlagSet("mytrader", flag.ContinueOnError)
+	var b bookFlags
+	b.register(fs)
+	fs.String("listen_addr", "localhost:9999", "")
+	fs.String("symbols", "default", "")
+	return fs
+}
+
+// writeConfig writes the yaml config file and loads it
+func writeConfig(t *testing.T, content string) (*config, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mytrader.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return loadConfig(path)
+}
+
+const testConfig = `
+listen_addr: localhost:19999
+max_queue_size: 1000
+order_expired: 600
+books:
+  - symbol: BTCUSD
+    price_scale: 2
+    tick_size: "0.25"
+    max_queue_size: 5000
+  - symbol: ETHUSD
+`
+
+func TestConfigUnknownKeys(t *testing.T) {
+	for _, content := range []string{
+		"listen_adr: localhost:9999\n",
+		"books:\n  - symbol: BTCUSD\n    tick: 1\n",
+	} {
+		cfg, err := writeConfig(t, content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fs := newTestFlags()
+		overridden, err := applyOverrides(fs, cfg)
+		if err == nil {
+			_, err = bookSettings(fs, cfg, overridden, "BTCUSD")
+		}
+		if err == nil || !strings.Contains(err.Error(), "unknown key") {
+			t.Fatalf("the unknown key of %q should be rejected, but got %v", content, err)
+		}
+	}
+
+	for _, content := range []string{
+		"books: BTCUSD\n",
+		"books:\n  - price_scale: 2\n",
+		"max_queue_size: [1, 2]\n",
+	} {
+		cfg, err := writeConfig(t, content)
+		if err == nil {
+			_, err = applyOverrides(newTestFlags(), cfg)
+		}
+		if err == nil {
+			t.Fatalf("the bad config %q should be rejected", content)
+		}
+	}
+}
+
+func TestConfigBooks(t *testing.T) {
+	cfg, err := writeConfig(t, testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := newTestFlags()
+	overridden, err := applyOverrides(fs, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr := fs.Lookup("listen_addr").Value.String(); addr != "localhost:19999" {
+		t.Fatal("the server flag should be set by the file", addr)
+	}
+
+	// the settings of the book override the top-level ones of the file
+	btc, err := bookSettings(fs, cfg, overridden, "BTCUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if btc.maxQueueSize != 5000 || btc.orderExpired != 600 || btc.priceScale != 2 || btc.tickSize != "0.25" {
+		t.Fatalf("wrong settings of BTCUSD: %+v", *btc)
+	}
+	eth, err := bookSettings(fs, cfg, overridden, "ETHUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eth.maxQueueSize != 1000 || eth.orderExpired != 600 || eth.priceScale != 0 {
+		t.Fatalf("wrong settings of ETHUSD: %+v", *eth)
+	}
+	if _, err := btc.options(); err != nil {
+		t.Fatal(err)
+	}
+
+	if symbols := bookSymbols("default", cfg, overridden); !reflect.DeepEqual(symbols, []string{"BTCUSD", "ETHUSD"}) {
+		t.Fatal("the symbols should be the books of the file", symbols)
+	}
+}
+
+func TestConfigOverrides(t *testing.T) {
+	cfg, err := writeConfig(t, testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the environment variables override the file, including the books
+	t.Setenv("MYTRADER_MAX_QUEUE_SIZE", "200")
+	t.Setenv("MYTRADER_ORDER_EXPIRED", "60")
+	t.Setenv("MYTRADER_LISTEN_ADDR", "localhost:29999")
+	fs := newTestFlags()
+	// the flags override the environment variables
+	if err := fs.Parse([]string{"-order_expired", "30", "-symbols", "BTCUSD, XRPUSD"}); err != nil {
+		t.Fatal(err)
+	}
+	overridden, err := applyOverrides(fs, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr := fs.Lookup("listen_addr").Value.String(); addr != "localhost:29999" {
+		t.Fatal("the server flag should be set by the environment variable", addr)
+	}
+	btc, err := bookSettings(fs, cfg, overridden, "BTCUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if btc.maxQueueSize != 200 || btc.orderExpired != 30 || btc.priceScale != 2 {
+		t.Fatalf("wrong settings of BTCUSD: %+v", *btc)
+	}
+
+	// the symbols override the books of the file, the symbol without a book gets the defaults
+	symbols := bookSymbols(fs.Lookup("symbols").Value.String(), cfg, overridden)
+	if !reflect.DeepEqual(symbols, []string{"BTCUSD", "XRPUSD"}) {
+		t.Fatal("the symbols should be overridden by the flag", symbols)
+	}
+	xrp, err := bookSettings(fs, cfg, overridden, "XRPUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if xrp.maxQueueSize != 200 || xrp.orderExpired != 30 || xrp.priceScale != 0 {
+		t.Fatalf("wrong settings of XRPUSD: %+v", *xrp)
+	}
+
+	// the bad value of the environment variable is rejected
+	t.Setenv("MYTRADER_PRICE_SCALE", "two")
+	if _, err := applyOverrides(newTestFlags(), cfg); err == nil || !strings.Contains(err.Error(), "MYTRADER_PRICE_SCALE") {
+		t.Fatal("the bad environment variable should be rejected", err)
+	}
+}
@@ -1,10 +1,16 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
 	"runtime"
+	"syscall"
 	"time"
 
 	"mytrader.github.com/orderbook"
@@ -15,19 +21,41 @@ var GitCommit string // git describe --always
 
 func main() {
 
-	// clean freq.,  queue size, server addr and order expiration
+	// server addr, journal, snapshot, auth and the defaults of the orderbooks
 	var (
-		cleanOrderFreq int64
-		maxQueueSize   int
-		serverAddr     string
-		orderExpired   int64
-		version        bool
+		book          bookFlags
+		serverAddr    string
+		symbols       string
+		journalDir    string
+		journalSync   string
+		journalSyncMs int64
+		snapshotDir   string
+		snapshotSec   int64
+		accounts      bool
+		tlsCert       string
+		tlsKey        string
+		tlsClientCA   string
+		credentials   string
+		riskConfig    string
+		configFile    string
+		version       bool
 	)
 
-	flag.Int64Var(&cleanOrderFreq, "clean_order_freq", 10, "freq. of auto clean order in second")
-	flag.IntVar(&maxQueueSize, "max_queue_size", 100, "max. size of queue")
+	book.register(flag.CommandLine)
 	flag.StringVar(&serverAddr, "listen_addr", "localhost:9999", "address of the server")
-	flag.Int64Var(&orderExpired, "order_expired", 86400, "expiration of the order, this is used by auto cleaner")
+	flag.StringVar(&symbols, "symbols", "default", "symbols of the orderbooks, separated by comma")
+	flag.StringVar(&journalDir, "journal_dir", "", "directory of the journals of the orderbooks, the journal is disabled if it is empty")
+	flag.StringVar(&journalSync, "journal_sync", "always", "sync policy of the journal [always|interval|none]")
+	flag.Int64Var(&journalSyncMs, "journal_sync_interval", 1000, "interval of syncing the journal in millisecond, this is used by the interval policy")
+	flag.StringVar(&snapshotDir, "snapshot_dir", "", "directory of the snapshots of the orderbooks, the snapshot is disabled if it is empty")
+	flag.Int64Var(&snapshotSec, "snapshot_interval", 300, "interval of taking the snapshots in second, the journal is truncated after each snapshot")
+	flag.BoolVar(&accounts, "accounts", false, "enable the balances of the accounts, the funds of the orders are checked and held before they trade, the balances are journaled and snapshotted with the orderbooks")
+	flag.StringVar(&tlsCert, "tls_cert", "", "certificate file of the server, the server accepts TLS connections only if it is set")
+	flag.StringVar(&tlsKey, "tls_key", "", "key file of the certificate of the server")
+	flag.StringVar(&tlsClientCA, "tls_client_ca", "", "CA file of the client certificates, the clients should have the certificates signed by it (mTLS)")
+	flag.StringVar(&credentials, "credentials", "", "json file of the api keys of the clients, the calls are not authenticated and the admin calls are denied if it is empty")
+	flag.StringVar(&riskConfig, "risk_config", "", "json file of the risk limits of the symbols, it is reloaded by SIGHUP")
+	flag.StringVar(&configFile, "config", "", "yaml file of the flags and the settings of each orderbook, the environment variables "+envPrefix+"<FLAG> and the flags override it")
 	flag.BoolVar(&version, "version", false, "show version")
 	flag.Parse()
 
@@ -42,16 +70,138 @@ func main() {
 		return
 	}
 
-	// setup orderbook
-	orderbook.OrderExpiration = time.Duration(orderExpired) * time.Second
-	orderbook.MaxQueueSize = maxQueueSize
-	ob, err := orderbook.New(orderbook.WithCleanTimeFrequecy(time.Duration(cleanOrderFreq) * time.Second))
+	// the environment variables are applied first, so the config file can be set by them too
+	if _, err := applyOverrides(flag.CommandLine, nil); err != nil {
+		panic(err)
+	}
+	var cfg *config
+	if len(configFile) > 0 {
+		var err error
+		if cfg, err = loadConfig(configFile); err != nil {
+			panic(err)
+		}
+	}
+	overridden, err := applyOverrides(flag.CommandLine, cfg)
 	if err != nil {
 		panic(err)
 	}
 
+	policy, err := orderbook.ParseSyncPolicy(journalSync)
+	if err != nil {
+		panic(err)
+	}
+	for _, dir := range []string{journalDir, snapshotDir} {
+		if len(dir) > 0 {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	risks, err := loadRiskConfig(riskConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	serverOpts, err := authOptions(tlsCert, tlsKey, tlsClientCA, credentials)
+	if err != nil {
+		panic(err)
+	}
+	symbolList := bookSymbols(symbols, cfg, overridden)
+
+	// the balances of the accounts are shared by the orderbooks, the ledger has its own journal and snapshot
+	var ledger *orderbook.Ledger
+	if accounts {
+		var j *orderbook.Journal
+		ledger, j, err = openLedger(symbolList, journalDir, snapshotDir, policy,
+			time.Duration(journalSyncMs)*time.Millisecond, time.Duration(snapshotSec)*time.Second)
+		if err != nil {
+			panic(err)
+		}
+		// the journal of the ledger is closed after the orderbooks
+		if j != nil {
+			defer j.Close()
+		}
+		serverOpts = append(serverOpts, server.WithLedger(ledger))
+	}
+
+	// setup exchange, each symbol has its own orderbook
+	ex := orderbook.NewExchange()
+	books := make([]*orderbook.OrderBook, 0, len(symbolList))
+	for _, symbol := range symbolList {
+		settings, err := bookSettings(flag.CommandLine, cfg, overridden, symbol)
+		if err != nil {
+			panic(err)
+		}
+		opts, err := settings.options()
+		if err != nil {
+			panic(err)
+		}
+		opts = append(opts, orderbook.WithRiskConfig(riskConfigOf(risks, symbol)))
+		if ledger != nil {
+			opts = append(opts, orderbook.WithLedger(ledger))
+		}
+
+		// each orderbook has its own journal
+		var j *orderbook.Journal
+		if len(journalDir) > 0 {
+			j, err = orderbook.OpenJournal(
+				filepath.Join(journalDir, symbol+".journal"),
+				orderbook.WithSyncPolicy(policy),
+				orderbook.WithSyncInterval(time.Duration(journalSyncMs)*time.Millisecond),
+			)
+			if err != nil {
+				panic(err)
+			}
+			defer j.Close()
+			opts = append(opts, orderbook.WithJournal(j))
+		}
+
+		if len(snapshotDir) > 0 {
+			opts = append(opts, orderbook.WithSnapshot(
+				filepath.Join(snapshotDir, symbol+".snapshot"),
+				time.Duration(snapshotSec)*time.Second,
+			))
+		}
+
+		ob, err := ex.AddSymbol(symbol, opts...)
+		if err != nil {
+			panic(err)
+		}
+
+		// rebuild the orderbook by the snapshot and the journal after it before the server accepts connections
+		if len(snapshotDir) > 0 {
+			if err := ob.LoadSnapshot(); err != nil {
+				panic(err)
+			}
+		}
+		if j != nil {
+			if err := ob.Replay(); err != nil {
+				panic(err)
+			}
+			log.Printf("[%s] the orderbook is rebuilt from %s\n", symbol, j.Path())
+		}
+		books = append(books, ob)
+	}
+
+	if ledger != nil {
+		if n := ledger.ReleaseStale(books...); n > 0 {
+			log.Printf("the funds of %d orders which are not in the orderbooks are released\n", n)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go ledger.AutoSnapshot(ctx)
+	}
+
+	// the orderbooks are closed before their journals
+	defer ex.Close()
+
+	if len(riskConfig) > 0 {
+		go reloadRiskConfig(ex, riskConfig)
+	}
+
 	// setup server
-	s, err := server.New(server.WithOrderBook(ob), server.WithAddr(serverAddr))
+	s, err := server.New(append(serverOpts, server.WithExchange(ex), server.WithAddr(serverAddr))...)
 	if err != nil {
 		panic(err)
 	}
@@ -62,3 +212,155 @@ func main() {
 
 	log.Println("service is stopped")
 }
+
+// ledgerName is the name of the journal and the snapshot of the ledger in their directories
+const ledgerName = "ledger"
+
+// openLedger returns the ledger which is rebuilt by its snapshot and journal and the journal, they are in the
+// directories of the ones of the orderbooks and the journal is nil if the journal directory is empty
+func openLedger(symbols []string, journalDir, snapshotDir string, policy orderbook.SyncPolicy, syncInterval, snapshotInterval time.Duration) (*orderbook.Ledger, *orderbook.Journal, error) {
+	for _, symbol := range symbols {
+		if symbol == ledgerName && (len(journalDir) > 0 || len(snapshotDir) > 0) {
+			return nil, nil, fmt.Errorf("the symbol %s is the name of the journal of the ledger", symbol)
+		}
+	}
+
+	var opts []orderbook.LedgerOption
+	var j *orderbook.Journal
+	if len(journalDir) > 0 {
+		var err error
+		j, err = orderbook.OpenJournal(
+			filepath.Join(journalDir, ledgerName+".journal"),
+			orderbook.WithSyncPolicy(policy),
+			orderbook.WithSyncInterval(syncInterval),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, orderbook.WithLedgerJournal(j))
+	}
+	if len(snapshotDir) > 0 {
+		opts = append(opts, orderbook.WithLedgerSnapshot(filepath.Join(snapshotDir, ledgerName+".snapshot"), snapshotInterval))
+	}
+	ledger, err := orderbook.NewLedger(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(snapshotDir) > 0 {
+		if err := ledger.LoadSnapshot(); err != nil {
+			return nil, nil, err
+		}
+	}
+	if j != nil {
+		if err := ledger.Replay(); err != nil {
+			return nil, nil, err
+		}
+		log.Printf("the ledger is rebuilt from %s\n", j.Path())
+	}
+	return ledger, j, nil
+}
+
+// parseInstrument returns the instrument of the scales and the decimal sizes, the empty tick and lot are the
+// smallest units of the scales and the empty min. quantity is the lot
+func parseInstrument(priceScale, qtyScale int, tickSize, lotSize, minQty, maxQty string) (orderbook.Instrument, error) {
+	inst := orderbook.Instrument{PriceScale: priceScale, QtyScale: qtyScale, TickSize: 1, LotSize: 1}
+	var err error
+	if len(tickSize) > 0 {
+		if inst.TickSize, err = inst.ParsePrice(tickSize); err != nil {
+			return inst, err
+		}
+	}
+	if len(lotSize) > 0 {
+		if inst.LotSize, err = inst.ParseQty(lotSize); err != nil {
+			return inst, err
+		}
+	}
+	inst.MinQty = inst.LotSize
+	if len(minQty) > 0 {
+		if inst.MinQty, err = inst.ParseQty(minQty); err != nil {
+			return inst, err
+		}
+	}
+	if inst.MaxQty, err = inst.ParseQty(maxQty); err != nil {
+		return inst, err
+	}
+	return inst, inst.Validate()
+}
+
+// authOptions returns the server options of TLS, mTLS and the api keys of the clients in the json file
+func authOptions(certFile, keyFile, clientCA, credentials string) ([]server.Option, error) {
+	var opts []server.Option
+	if len(certFile) > 0 || len(keyFile) > 0 {
+		opts = append(opts, server.WithTLS(certFile, keyFile))
+	}
+	if len(clientCA) > 0 {
+		opts = append(opts, server.WithClientCA(clientCA))
+	}
+	if len(credentials) > 0 {
+		b, err := os.ReadFile(credentials)
+		if err != nil {
+			return nil, err
+		}
+		var creds []server.Credential
+		if err := json.Unmarshal(b, &creds); err != nil {
+			return nil, fmt.Errorf("%s: %w", credentials, err)
+		}
+		opts = append(opts, server.WithCredentials(creds...))
+	}
+	return opts, nil
+}
+
+// loadRiskConfig returns the risk limits of the symbols in the json file, the limits of "*" are used by the symbols
+// which are not in the file. There is no limit if the path is empty.
+func loadRiskConfig(path string) (map[string]orderbook.RiskConfig, error) {
+	risks := make(map[string]orderbook.RiskConfig)
+	if len(path) == 0 {
+		return risks, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &risks); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for symbol, cfg := range risks {
+		if err := cfg.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: symbol %q: %w", path, symbol, err)
+		}
+	}
+	return risks, nil
+}
+
+// riskConfigOf returns the risk limits of the symbol
+func riskConfigOf(risks map[string]orderbook.RiskConfig, symbol string) orderbook.RiskConfig {
+	if cfg, exist := risks[symbol]; exist {
+		return cfg
+	}
+	return risks["*"]
+}
+
+// reloadRiskConfig reloads the risk limits of the orderbooks from the json file when the process receives SIGHUP,
+// the limits are not changed if the file is bad
+func reloadRiskConfig(ex *orderbook.Exchange, path string) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for range hup {
+		risks, err := loadRiskConfig(path)
+		if err != nil {
+			log.Println("failed to reload the risk limits:", err)
+			continue
+		}
+		for _, symbol := range ex.Symbols() {
+			ob, err := ex.OrderBook(symbol)
+			if err != nil {
+				continue
+			}
+			if err := ob.SetRiskConfig(riskConfigOf(risks, symbol)); err != nil {
+				log.Printf("[%s] failed to reload the risk limits: %v\n", symbol, err)
+			}
+		}
+		log.Println("the risk limits are reloaded from", path)
+	}
+}
@@ -0,0 +1,117 @@
+package orderbook
+
+import (
+	"time"
+)
+
+// Settings are the runtime settings of the orderbook
+type Settings struct {
+	// MaxQueueSize is the max. number of the resting orders of each side
+	MaxQueueSize int `json:"max_queue_size"`
+	// OrderExpiration is the live time of the orders and the history
+	OrderExpiration time.Duration `json:"order_expiration"`
+}
+
+// Settings returns the runtime settings of the orderbook
+func (ob *OrderBook) Settings() Settings {
+	v := ob.loadState()
+	return Settings{MaxQueueSize: v.maxQueueSize, OrderExpiration: v.orderExpiration}
+}
+
+// SetMaxQueueSize changes the max. size of the queue of each side, the resting orders over the size are kept but
+// the new orders are rejected until the size of the queue is less than it
+func (ob *OrderBook) SetMaxQueueSize(size int) error {
+	if size < 1 {
+		return ErrBadQueueSize
+	}
+	return ob.exec(func() error {
+		ob.maxQueueSize = size
+		return nil
+	})
+}
+
+// SetOrderExpiration changes the live time of the orders and the history, it is applied by the next run of the
+// auto-cleaner
+func (ob *OrderBook) SetOrderExpiration(expiration time.Duration) error {
+	if expiration <= 0 {
+		return ErrBadOrderExpiration
+	}
+	return ob.exec(func() error {
+		ob.orderExpiration = expiration
+		return nil
+	})
+}
+
+// CancelFilter selects the resting and untriggered stop orders which are canceled by CancelAll, all the orders are
+// selected if it is empty
+type CancelFilter struct {
+	// Side is the side of the orders, both of the sides if it is nil
+	Side *Side
+	// Account is the account of the orders, all the accounts if it is empty
+	Account string
+}
+
+// match checks if the order is selected by the filter
+func (f CancelFilter) match(o *Order) bool {
+	if f.Side != nil && o.Side != *f.Side {
+		return false
+	}
+	return len(f.Account) == 0 || o.Account == f.Account
+}
+
+// CancelAll cancels the resting and untriggered stop orders which are selected by the filter in one command and
+// returns the number of the canceled orders. Each cancel is journaled, the orders after the one which fails to be
+// journaled are kept.
+func (ob *OrderBook) CancelAll(filter CancelFilter) (int, error) {
+	canceled := 0
+	err := ob.exec(func() error {
+		for _, q := range []*bookSide{ob.bids, ob.asks} {
+			for _, o := range q.orders() {
+				if !filter.match(o) {
+					continue
+				}
+				if err := ob.writeJournal(journalEntry{Type: journalCancel, ID: o.ID.String()}); err != nil {
+					return err
+				}
+				ob.cancel(q, o, ExecCanceled)
+				canceled++
+			}
+		}
+		for _, o := range ob.stops.orders() {
+			if !filter.match(o) {
+				continue
+			}
+			if err := ob.writeJournal(journalEntry{Type: journalCancel, ID: o.ID.String()}); err != nil {
+				return err
+			}
+			ob.cancel(nil, o, ExecCanceled)
+			canceled++
+		}
+		return nil
+	})
+	return canceled, err
+}
+
+// BookDump is the consistent copy of the state of the orderbook
+type BookDump struct {
+	Bids      Orders   `json:"bids"`
+	Asks      Orders   `json:"asks"`
+	Stops     Orders   `json:"stops"`
+	Halted    bool     `json:"halted"`
+	LastPrice int      `json:"last_price"`
+	Settings  Settings `json:"settings"`
+}
+
+// Dump returns the copy of the resting and stop orders and the state of the orderbook, it is read from the view of
+// the orderbook, so the matching is not blocked by it
+func (ob *OrderBook) Dump() BookDump {
+	v := ob.loadView()
+	return BookDump{
+		Bids:      copyOrders(v.bids),
+		Asks:      copyOrders(v.asks),
+		Stops:     copyOrders(v.stops),
+		Halted:    v.state.halted,
+		LastPrice: v.state.lastPrice,
+		Settings:  Settings{MaxQueueSize: v.state.maxQueueSize, OrderExpiration: v.state.orderExpiration},
+	}
+}
@@ -0,0 +1,149 @@
+package orderbook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSettings(t *testing.T) {
+
+	t.Log("start testing the runtime settings...")
+
+	clock := newTestClock()
+	ob, err := New(WithClock(clock.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	if s := ob.Settings(); s.MaxQueueSize != DefaultMaxQueueSize || s.OrderExpiration != DefaultOrderExpiration {
+		t.Fatal("the settings should be the defaults", s)
+	}
+	if _, err := New(WithMaxQueueSize(0)); err != ErrBadQueueSize {
+		t.Fatal("wrong error type", err)
+	}
+	if _, err := New(WithOrderExpiration(-time.Second)); err != ErrBadOrderExpiration {
+		t.Fatal("wrong error type", err)
+	}
+	other, err := New(WithMaxQueueSize(5), WithOrderExpiration(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	if s := other.Settings(); s.MaxQueueSize != 5 || s.OrderExpiration != time.Hour {
+		t.Fatal("the settings of each orderbook should be its own", s)
+	}
+	if err := ob.SetMaxQueueSize(0); err != ErrBadQueueSize {
+		t.Fatal("wrong error type", err)
+	}
+	if err := ob.SetOrderExpiration(0); err != ErrBadOrderExpiration {
+		t.Fatal("wrong error type", err)
+	}
+
+	if err := ob.SetMaxQueueSize(1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 99, 1); err != ErrTooLargeSizeOfQueue {
+		t.Fatal("wrong error type", err)
+	}
+	if err := ob.CheckQueueSize(Buy); err != ErrTooLargeSizeOfQueue {
+		t.Fatal("wrong error type", err)
+	}
+
+	// the new expiration is applied by the next run of the auto-cleaner
+	if err := ob.SetOrderExpiration(time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	clock.Add(time.Minute + time.Second)
+	ob.cleanOldOrder()
+	if n := len(ob.GetBids()); n != 0 {
+		t.Fatal("the order should be expired", n)
+	}
+	if s := ob.Settings(); s.MaxQueueSize != 1 || s.OrderExpiration != time.Minute {
+		t.Fatal("the settings should be changed", s)
+	}
+	t.Log("... Passed")
+}
+
+func TestCancelAll(t *testing.T) {
+
+	t.Log("start testing canceling all the orders...")
+
+	ex := NewExchange()
+	btc, err := ex.AddSymbol("BTCUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	eth, err := ex.AddSymbol("ETHUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ex.Close()
+
+	for _, ob := range []*OrderBook{btc, eth} {
+		for _, account := range []string{"alice", "bob"} {
+			if _, err := ob.ProcessLimitOrder(Buy, 99, 1, WithAccount(account)); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := ob.ProcessLimitOrder(Sell, 101, 1, WithAccount(account)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if _, err := btc.ProcessLimitOrder(Buy, 110, 1, WithAccount("alice"), WithStopPrice(105)); err != nil {
+		t.Fatal(err)
+	}
+
+	sell := Sell
+	if n, err := btc.CancelAll(CancelFilter{Side: &sell}); err != nil || n != 2 {
+		t.Fatal("the sell orders should be canceled", n, err)
+	}
+	if n, err := btc.CancelAll(CancelFilter{Account: "alice"}); err != nil || n != 2 {
+		t.Fatal("the buy and stop orders of alice should be canceled", n, err)
+	}
+	if bids, asks, stops := btc.GetBids(), btc.GetAsks(), btc.GetStops(); len(bids) != 1 || bids[0].Account != "bob" ||
+		len(asks) != 0 || len(stops) != 0 {
+		t.Fatal("only the buy order of bob should be kept", bids, asks, stops)
+	}
+
+	// the orders of all the orderbooks are canceled
+	if n, err := ex.CancelAll(CancelFilter{}); err != nil || n != 5 {
+		t.Fatal("all the orders should be canceled", n, err)
+	}
+	if d := eth.Dump(); len(d.Bids)+len(d.Asks)+len(d.Stops) != 0 {
+		t.Fatal("the orderbook should be empty", d)
+	}
+	t.Log("... Passed")
+}
+
+func TestDump(t *testing.T) {
+
+	t.Log("start testing the dump of the orderbook...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	ob.ProcessLimitOrder(Sell, 101, 2)
+	ob.ProcessLimitOrder(Buy, 101, 1)
+	ob.ProcessLimitOrder(Buy, 99, 1)
+	ob.ProcessLimitOrder(Sell, 110, 1, WithStopPrice(100))
+	ob.Halt()
+
+	d := ob.Dump()
+	if len(d.Bids) != 1 || len(d.Asks) != 1 || len(d.Stops) != 1 || !d.Halted || d.LastPrice != 101 {
+		t.Fatal("wrong dump of the orderbook", d)
+	}
+
+	// the dump is a copy
+	d.Bids[0].Qty = 100
+	if ob.GetBids()[0].Qty != 1 {
+		t.Fatal("the dump should not change the orderbook")
+	}
+	t.Log("... Passed")
+}
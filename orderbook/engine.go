@@ -0,0 +1,259 @@
+package orderbook
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// commandQueueSize is the size of the queue of the commands which wait for the matching loop
+	commandQueueSize = 1024
+	// maxBatchSize is the max number of the commands which are applied with one lock
+	maxBatchSize = 256
+)
+
+// command is the change of the orderbook which is applied by the matching loop
+type command struct {
+	fn   func() error
+	done chan error
+}
+
+// bookView is the read-only copy of the resting orders, it is never changed after it is published
+type bookView struct {
+	// version is the version of the orderbook when the view is built
+	version    uint64
+	bids, asks Orders
+	// bidLevels & askLevels are all the price levels of each side
+	bidLevels, askLevels []Level
+	// stops is the untriggered stop orders
+	stops Orders
+	// state is the state of the orderbook when the view is built
+	state bookState
+}
+
+// bookState is the small state of the orderbook which is published by the matching loop after each batch, the
+// frequent reads are served from it without building the view
+type bookState struct {
+	halted         bool
+	bidLen, askLen int
+	// lastPrice is the last trade price, maxQueueSize and orderExpiration are the settings of the orderbook
+	lastPrice       int
+	maxQueueSize    int
+	orderExpiration time.Duration
+}
+
+// orderEntry is the copy of the order and its status in the order index
+type orderEntry struct {
+	order  Order
+	status OrderStatus
+}
+
+// orderIndex is the copy of the resting, canceled and complete orders by id. The orders which are changed by a
+// batch are copied into it by the matching loop after the batch, so GetOrder never waits for the matching loop or
+// copies the book.
+type orderIndex struct {
+	sync.RWMutex
+	orders map[uuid.UUID]orderEntry
+}
+
+// side returns the copy of the resting orders of the side
+func (v *bookView) side(side Side) Orders {
+	if side == Buy {
+		return v.bids
+	}
+	return v.asks
+}
+
+// sideLen returns the number of the resting orders of the side
+func (v bookState) sideLen(side Side) int {
+	if side == Buy {
+		return v.bidLen
+	}
+	return v.askLen
+}
+
+// run is the matching loop which owns the orderbook, the commands are applied one by one in the order of the
+// queue until the orderbook is closed
+func (ob *OrderBook) run() {
+	defer close(ob.stopped)
+	batch := make([]command, 0, maxBatchSize)
+	for {
+		select {
+		case cmd := <-ob.cmds:
+			batch = append(batch[:0], cmd)
+			// the waiting commands are applied in the same batch, so the view is published once
+		drain:
+			for len(batch) < maxBatchSize {
+				select {
+				case cmd := <-ob.cmds:
+					batch = append(batch, cmd)
+				default:
+					break drain
+				}
+			}
+			ob.execute(batch)
+		case <-ob.stop:
+			return
+		}
+	}
+}
+
+// execute applies the batch of commands with lock and updates the version of the orderbook before the results
+// are returned, so the callers can read their changes from the next view
+func (ob *OrderBook) execute(batch []command) {
+	errs := make([]error, len(batch))
+	ob.Lock()
+	for i, cmd := range batch {
+		errs[i] = cmd.fn()
+		ob.publish()
+	}
+	ob.syncIndex()
+	ob.state.Store(ob.buildState())
+	atomic.AddUint64(&ob.version, 1)
+	ob.Unlock()
+
+	for i, cmd := range batch {
+		cmd.done <- errs[i]
+	}
+}
+
+// exec sends the command to the matching loop and waits for its result
+func (ob *OrderBook) exec(fn func() error) error {
+	cmd := command{fn: fn, done: make(chan error, 1)}
+	select {
+	case ob.cmds <- cmd:
+	case <-ob.stopped:
+		return ErrOrderBookClosed
+	}
+
+	select {
+	case err := <-cmd.done:
+		return err
+	case <-ob.stopped:
+		// the result is sent before the matching loop is stopped
+		select {
+		case err := <-cmd.done:
+			return err
+		default:
+			return ErrOrderBookClosed
+		}
+	}
+}
+
+// Close stops the matching loop, the commands after it are rejected with ErrOrderBookClosed
+func (ob *OrderBook) Close() {
+	ob.closeOnce.Do(func() { close(ob.stop) })
+	<-ob.stopped
+}
+
+// buildView builds the view of the resting orders with lock
+func (ob *OrderBook) buildView() *bookView {
+	return &bookView{
+		version:   atomic.LoadUint64(&ob.version),
+		bids:      copyOrders(ob.bids.orders()),
+		asks:      copyOrders(ob.asks.orders()),
+		bidLevels: ob.bids.depth(0),
+		askLevels: ob.asks.depth(0),
+		stops:     copyOrders(ob.stops.orders()),
+		state:     ob.buildState(),
+	}
+}
+
+// loadView returns the view of the latest version of the orderbook. The view is built by the first reader after
+// the orderbook is changed and shared by the other readers, so the matching loop never builds the views. The
+// readers of the orders by id and the state of the orderbook should use the order index and loadState instead.
+func (ob *OrderBook) loadView() *bookView {
+	if v := ob.view.Load().(*bookView); v.version == atomic.LoadUint64(&ob.version) {
+		return v
+	}
+	ob.RLock()
+	defer ob.RUnlock()
+	// the version is not changed while the lock is held, so the readers which build the view at the same time
+	// build the same one
+	v := ob.view.Load().(*bookView)
+	if v.version != atomic.LoadUint64(&ob.version) {
+		v = ob.buildView()
+		ob.view.Store(v)
+	}
+	return v
+}
+
+// buildState returns the state of the orderbook with lock
+func (ob *OrderBook) buildState() bookState {
+	return bookState{
+		halted:          ob.halted,
+		bidLen:          ob.bids.Len(),
+		askLen:          ob.asks.Len(),
+		lastPrice:       ob.lastPrice,
+		maxQueueSize:    ob.maxQueueSize,
+		orderExpiration: ob.orderExpiration,
+	}
+}
+
+// loadState returns the state of the orderbook which is published after the last batch
+func (ob *OrderBook) loadState() bookState {
+	return ob.state.Load().(bookState)
+}
+
+// touch marks the order which is changed by the command, it is copied into the order index after the batch.
+// It should be called with lock.
+func (ob *OrderBook) touch(id uuid.UUID) {
+	ob.touched[id] = struct{}{}
+}
+
+// syncIndex copies the orders which are changed by the batch into the order index, the whole index is rebuilt
+// after the orderbook is restored. It should be called with lock.
+func (ob *OrderBook) syncIndex() {
+	if !ob.reindex && len(ob.touched) == 0 {
+		return
+	}
+	ob.index.Lock()
+	defer ob.index.Unlock()
+
+	if ob.reindex {
+		ob.reindex = false
+		ob.index.orders = make(map[uuid.UUID]orderEntry, len(ob.Done)+len(ob.Canceled)+ob.bids.Len()+ob.asks.Len())
+		for _, history := range []map[string]Order{ob.Done, ob.Canceled} {
+			for _, o := range history {
+				ob.touch(o.ID)
+			}
+		}
+		for _, orders := range [][]*Order{ob.bids.orders(), ob.asks.orders(), ob.stops.orders()} {
+			for _, o := range orders {
+				ob.touch(o.ID)
+			}
+		}
+	}
+
+	for id := range ob.touched {
+		if e, exist := ob.orderEntry(id); exist {
+			ob.index.orders[id] = e
+		} else {
+			delete(ob.index.orders, id)
+		}
+		delete(ob.touched, id)
+	}
+}
+
+// orderEntry returns the copy of the order and its status, the resting order is prior to the canceled order and
+// the complete order. It should be called with lock.
+func (ob *OrderBook) orderEntry(id uuid.UUID) (orderEntry, bool) {
+	for _, q := range []*bookSide{ob.bids, ob.asks} {
+		if o := q.get(id); o != nil {
+			return orderEntry{*o, restingStatus(o)}, true
+		}
+	}
+	if o := ob.stops.get(id); o != nil {
+		return orderEntry{*o, restingStatus(o)}, true
+	}
+	if o, exist := ob.Canceled[id.String()]; exist {
+		return orderEntry{o, StatusCanceled}, true
+	}
+	if o, exist := ob.Done[id.String()]; exist {
+		return orderEntry{o, StatusCompleted}, true
+	}
+	return orderEntry{}, false
+}
@@ -0,0 +1,314 @@
+package orderbook
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentOrders(t *testing.T) {
+
+	t.Log("start testing the orderbook with concurrent commands and reads...")
+
+	ob, err := New(WithMaxQueueSize(100000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	const (
+		writers = 8
+		orders  = 100
+	)
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		ids = make([]string, 0, writers*orders)
+	)
+	stop := make(chan struct{})
+
+	// the readers never see a crossed book or a view which is not consistent
+	var readers sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			var order Order
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				depth := ob.Depth(1)
+				if len(depth.Bids) > 0 && len(depth.Asks) > 0 && depth.Bids[0].Price >= depth.Asks[0].Price {
+					t.Errorf("the book is crossed: %v", depth)
+					return
+				}
+				if bids := ob.GetBids(); len(bids) > 0 {
+					ob.GetOrder(bids[0].ID.String(), &order)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for j := 0; j < orders; j++ {
+				side := Side(r.Intn(2))
+				id, err := ob.ProcessLimitOrder(side, 95+r.Intn(10), 1+r.Intn(20))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				mu.Lock()
+				ids = append(ids, id)
+				mu.Unlock()
+
+				switch r.Intn(4) {
+				case 0:
+					ob.CancelOrder(id)
+				case 1:
+					ob.AmendOrder(id, 95+r.Intn(10), 1+r.Intn(20))
+				}
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+	close(stop)
+	readers.Wait()
+
+	// the filled qty of each order is the total qty of its fills
+	var order Order
+	for _, id := range ids {
+		status, err := ob.GetOrder(id, &order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		qty := 0
+		for _, fill := range ob.GetOrderFills(id) {
+			qty += fill.Qty
+		}
+		if status != StatusCompleted && qty != order.FilledQty {
+			t.Fatalf("the filled qty of order[%s] should be %d, but got %d", id, qty, order.FilledQty)
+		}
+	}
+
+	// the view is the same as the book
+	for _, side := range []Side{Buy, Sell} {
+		want, got := ob.own(side).orders(), ob.loadView().side(side)
+		if len(want) != len(got) {
+			t.Fatalf("[%s] the view should have %d orders, but got %d", side, len(want), len(got))
+		}
+		for i := range want {
+			if want[i].ID != got[i].ID || want[i].Qty != got[i].Qty {
+				t.Fatalf("[%s] the order[%d] of the view should be %s, but got %s", side, i, want[i], got[i])
+			}
+			// the order index is the same as the book
+			if status, err := ob.GetOrder(want[i].ID.String(), &order); err != nil || status != restingStatus(want[i]) ||
+				order.Qty != want[i].Qty || order.FilledQty != want[i].FilledQty {
+				t.Fatalf("[%s] the order[%d] of the index should be %s, but got %s, %s", side, i, want[i], status, order)
+			}
+		}
+	}
+	known := make(map[string]bool)
+	for _, history := range []map[string]Order{ob.Done, ob.Canceled} {
+		for id := range history {
+			known[id] = true
+		}
+	}
+	for _, o := range append(ob.bids.orders(), ob.asks.orders()...) {
+		known[o.ID.String()] = true
+	}
+	if n := len(ob.index.orders); n != len(known) {
+		t.Fatalf("the index should only have the %d resting orders and the history, but got %d", len(known), n)
+	}
+	t.Log("... Passed")
+}
+
+func TestClosedOrderBook(t *testing.T) {
+
+	t.Log("start testing the closed orderbook...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := ob.ProcessLimitOrder(Buy, 100, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ob.Close()
+	ob.Close() // closing twice is safe
+
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 10); err != ErrOrderBookClosed {
+		t.Fatal("wrong error type", err)
+	}
+	if err := ob.CancelOrder(id); err != ErrOrderBookClosed {
+		t.Fatal("wrong error type", err)
+	}
+	if _, ch := ob.SubscribeMarketData(0, 1); ch == nil {
+		t.Fatal("the channel should be closed")
+	} else if _, ok := <-ch; ok {
+		t.Fatal("the channel should be closed")
+	}
+
+	// the last view can still be read
+	var order Order
+	if status, err := ob.GetOrder(id, &order); err != nil || status != StatusPending {
+		t.Fatal("the order should be pending", status, err)
+	}
+	t.Log("... Passed")
+}
+
+// BenchmarkProcessLimitOrderParallel measures the throughput of the matching loop with concurrent clients
+func BenchmarkProcessLimitOrderParallel(b *testing.B) {
+	ob, err := New(WithMaxQueueSize(1 << 30))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer ob.Close()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			if _, err := ob.ProcessLimitOrder(Side(r.Intn(2)), 95+r.Intn(10), 1+r.Intn(20)); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+// BenchmarkGetOrderParallel measures the throughput of the reads from the view while the orders are processed
+func BenchmarkGetOrderParallel(b *testing.B) {
+	ob, err := New()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer ob.Close()
+
+	ids := make([]string, 0, 50)
+	for i := 0; i < 50; i++ {
+		id, err := ob.ProcessLimitOrder(Buy, 50+i, 10)
+		if err != nil {
+			b.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			ob.AmendOrder(ids[i%len(ids)], 50+i%len(ids), 1+i%10)
+		}
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		var order Order
+		i := 0
+		for pb.Next() {
+			if _, err := ob.GetOrder(ids[i%len(ids)], &order); err != nil {
+				b.Error(err)
+				return
+			}
+			i++
+		}
+	})
+}
+
+// deepBookSize is the number of the resting orders of the deep-book benchmarks
+const deepBookSize = 20000
+
+// newDeepBook returns the orderbook with the resting orders of both sides which do not cross and their ids
+func newDeepBook(b *testing.B) (*OrderBook, []string) {
+	b.Helper()
+	ob, err := New(WithMaxQueueSize(1 << 30))
+	if err != nil {
+		b.Fatal(err)
+	}
+	ids := make([]string, 0, deepBookSize)
+	for i := 0; i < deepBookSize; i++ {
+		side, price := Buy, 1000-i%500
+		if i%2 == 1 {
+			side, price = Sell, 1100+i%500
+		}
+		id, err := ob.ProcessLimitOrder(side, price, 10)
+		if err != nil {
+			b.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	return ob, ids
+}
+
+// BenchmarkProcessAndGetOrderDeepBook measures the order entry followed by the read of the order with a deep book,
+// the read after each change should not copy the book
+func BenchmarkProcessAndGetOrderDeepBook(b *testing.B) {
+	ob, _ := newDeepBook(b)
+	defer ob.Close()
+
+	var order Order
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		id, err := ob.ProcessLimitOrder(Buy, 1000-i%500, 1)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := ob.GetOrder(id, &order); err != nil {
+			b.Fatal(err)
+		}
+		if err := ob.CheckQueueSize(Buy); err != nil || ob.Halted() {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetOrderDeepBookParallel measures the reads of the orders with a deep book while the orders are amended
+func BenchmarkGetOrderDeepBookParallel(b *testing.B) {
+	ob, ids := newDeepBook(b)
+	defer ob.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for i := 0; ; i += 2 {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			ob.AmendOrder(ids[i%len(ids)], 1000-i%500, 1+i%10)
+		}
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		var order Order
+		i := 0
+		for pb.Next() {
+			if _, err := ob.GetOrder(ids[i%len(ids)], &order); err != nil {
+				b.Error(err)
+				return
+			}
+			i++
+		}
+	})
+}
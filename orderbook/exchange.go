@@ -0,0 +1,151 @@
+package orderbook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Exchange manages the orderbooks by symbol
+type Exchange struct {
+	sync.RWMutex
+	books map[string]*OrderBook
+
+	// ctx is the context of the auto-cleaners, it is nil if the auto-cleaners are not started
+	ctx context.Context
+}
+
+// NewExchange returns an Exchange without any orderbook
+func NewExchange() *Exchange {
+	return &Exchange{books: make(map[string]*OrderBook)}
+}
+
+// AddSymbol creates the orderbook with its options for the symbol
+func (ex *Exchange) AddSymbol(symbol string, opts ...Option) (*OrderBook, error) {
+	ob, err := New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := ex.AddOrderBook(symbol, ob); err != nil {
+		return nil, err
+	}
+	return ob, nil
+}
+
+// AddOrderBook adds the orderbook for the symbol, the auto-cleaner and the auto-snapshot of the orderbook are
+// started if the ones of the exchange are running
+func (ex *Exchange) AddOrderBook(symbol string, ob *OrderBook) error {
+	if len(symbol) == 0 {
+		return ErrBadSymbol
+	}
+
+	ex.Lock()
+	defer ex.Unlock()
+	if _, exist := ex.books[symbol]; exist {
+		return ErrSymbolExists
+	}
+	ex.books[symbol] = ob
+
+	if ex.ctx != nil {
+		go ob.AutoCleanOrderQueue(ex.ctx)
+		go ob.AutoSnapshot(ex.ctx)
+	}
+	return nil
+}
+
+// Symbols returns the sorted symbols of the exchange
+func (ex *Exchange) Symbols() []string {
+	ex.RLock()
+	defer ex.RUnlock()
+	symbols := make([]string, 0, len(ex.books))
+	for symbol := range ex.books {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// OrderBook returns the orderbook of the symbol
+func (ex *Exchange) OrderBook(symbol string) (*OrderBook, error) {
+	ex.RLock()
+	defer ex.RUnlock()
+	ob, exist := ex.books[symbol]
+	if !exist {
+		return nil, ErrSymbolNotFound
+	}
+	return ob, nil
+}
+
+// Halt halts the trading of the symbol
+func (ex *Exchange) Halt(symbol string) error {
+	ob, err := ex.OrderBook(symbol)
+	if err != nil {
+		return err
+	}
+	ob.Halt()
+	return nil
+}
+
+// Resume resumes the trading of the symbol
+func (ex *Exchange) Resume(symbol string) error {
+	ob, err := ex.OrderBook(symbol)
+	if err != nil {
+		return err
+	}
+	ob.Resume()
+	return nil
+}
+
+// CancelAll cancels the orders which are selected by the filter in all the orderbooks and returns the number of
+// the canceled orders
+func (ex *Exchange) CancelAll(filter CancelFilter) (int, error) {
+	canceled := 0
+	for _, symbol := range ex.Symbols() {
+		ob, err := ex.OrderBook(symbol)
+		if err != nil {
+			continue
+		}
+		n, err := ob.CancelAll(filter)
+		canceled += n
+		if err != nil {
+			return canceled, fmt.Errorf("%s: %w", symbol, err)
+		}
+	}
+	return canceled, nil
+}
+
+// Close stops the matching loop of each orderbook
+func (ex *Exchange) Close() {
+	ex.RLock()
+	defer ex.RUnlock()
+	for _, ob := range ex.books {
+		ob.Close()
+	}
+}
+
+// Info prints the information of each orderbook
+func (ex *Exchange) Info() {
+	for _, symbol := range ex.Symbols() {
+		if ob, err := ex.OrderBook(symbol); err == nil {
+			ob.Info()
+		}
+	}
+}
+
+// AutoCleanOrderQueue runs the auto-cleaner and the auto-snapshot of each orderbook until the ctx is done
+func (ex *Exchange) AutoCleanOrderQueue(ctx context.Context) {
+	ex.Lock()
+	ex.ctx = ctx
+	for _, ob := range ex.books {
+		go ob.AutoCleanOrderQueue(ctx)
+		go ob.AutoSnapshot(ctx)
+	}
+	ex.Unlock()
+
+	<-ctx.Done()
+
+	ex.Lock()
+	ex.ctx = nil
+	ex.Unlock()
+}
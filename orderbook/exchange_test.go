@@ -0,0 +1,142 @@
+package orderbook
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestExchangeSymbols(t *testing.T) {
+
+	t.Log("start testing symbols of the exchange...")
+
+	ex := NewExchange()
+	for _, symbol := range []string{"ETHUSD", "BTCUSD"} {
+		if _, err := ex.AddSymbol(symbol); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := ex.AddSymbol("BTCUSD"); err != ErrSymbolExists {
+		t.Fatal("wrong error type", err)
+	}
+	if _, err := ex.AddSymbol(""); err != ErrBadSymbol {
+		t.Fatal("wrong error type", err)
+	}
+	if _, err := ex.AddSymbol("XRPUSD", WithSessionEnd(-time.Hour)); err != ErrBadSessionEnd {
+		t.Fatal("wrong error type", err)
+	}
+	if _, err := ex.OrderBook("XRPUSD"); err != ErrSymbolNotFound {
+		t.Fatal("wrong error type", err)
+	}
+
+	symbols := ex.Symbols()
+	if len(symbols) != 2 || symbols[0] != "BTCUSD" || symbols[1] != "ETHUSD" {
+		t.Fatal("wrong symbols of the exchange", symbols)
+	}
+
+	t.Log("... Passed")
+}
+
+func TestExchangeRouting(t *testing.T) {
+
+	t.Log("start testing orderbooks of the exchange...")
+
+	ex := NewExchange()
+	btc, err := ex.AddSymbol("BTCUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	eth, err := ex.AddSymbol("ETHUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the orders of different symbols are not traded
+	if _, err := btc.ProcessLimitOrder(Buy, 100, 10); err != nil {
+		t.Fatal(err)
+	}
+	id, err := eth.ProcessLimitOrder(Sell, 100, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order Order
+	if status, _ := eth.GetOrder(id, &order); status != StatusPending {
+		t.Fatalf("the status of order[%s] should be: %s, but got %s", id, StatusPending, status)
+	}
+	if _, err := btc.GetOrder(id, &order); err != ErrDataNotFound {
+		t.Fatal("wrong error type", err)
+	}
+
+	// the halted symbol rejects the new orders, but the resting orders can be canceled
+	if err := ex.Halt("ETHUSD"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := eth.ProcessLimitOrder(Buy, 100, 10); err != ErrTradingHalted {
+		t.Fatal("wrong error type", err)
+	}
+	if err := eth.AmendOrder(id, 101, 10); err != ErrTradingHalted {
+		t.Fatal("wrong error type", err)
+	}
+	if _, err := btc.ProcessLimitOrder(Buy, 100, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := eth.CancelOrder(id); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ex.Resume("ETHUSD"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := eth.ProcessLimitOrder(Buy, 100, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := ex.Halt("XRPUSD"); err != ErrSymbolNotFound {
+		t.Fatal("wrong error type", err)
+	}
+
+	t.Log("... Passed")
+}
+
+func TestExchangeAutoClean(t *testing.T) {
+
+	t.Log("start testing auto-cleaners of the exchange...")
+
+	ex := NewExchange()
+	ctx, cancel := context.WithCancel(context.Background())
+	go ex.AutoCleanOrderQueue(ctx)
+	defer cancel()
+
+	// the orderbook which is added after starting the auto-cleaners is cleaned as well
+	for running := false; !running; runtime.Gosched() {
+		ex.RLock()
+		running = ex.ctx != nil
+		ex.RUnlock()
+	}
+	clock := newTestClock()
+	ob, err := ex.AddSymbol("BTCUSD", WithCleanTimeFrequecy(time.Millisecond), WithClock(clock.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired := ob.SubscribeExecutions(ExecutionFilter{}, 8)
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 10, WithTimeInForce(GTD), WithExpireTime(clock.Now().Add(50*time.Millisecond))); err != nil {
+		t.Fatal(err)
+	}
+	clock.Add(100 * time.Millisecond)
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case r := <-expired:
+			done = r.Type == ExecExpired
+		case <-timeout:
+			t.Fatal("the order should be expired by the auto cleaner")
+		}
+	}
+
+	if len(ob.GetBids()) != 0 {
+		t.Fatal("the size of bids should be 0")
+	}
+
+	t.Log("... Passed")
+}
@@ -0,0 +1,152 @@
+package orderbook
+
+import (
+	"fmt"
+	"time"
+)
+
+// ExecType is the type of the execution report
+type ExecType int
+
+const (
+	// ExecNew is reported when the order is accepted by the orderbook
+	ExecNew ExecType = iota
+	// ExecPartialFill is reported when the order is filled partially
+	ExecPartialFill
+	// ExecFill is reported when the order is filled completely
+	ExecFill
+	// ExecCanceled is reported when the order or its unfilled part is canceled
+	ExecCanceled
+	// ExecExpired is reported when the order is expired by the auto-cleaner
+	ExecExpired
+	// ExecRejected is reported when the order is rejected by the orderbook
+	ExecRejected
+	// ExecReplaced is reported when the order is amended
+	ExecReplaced
+	// ExecTriggered is reported when the stop order is triggered by the last trade price
+	ExecTriggered
+)
+
+func (e ExecType) String() string {
+	return [...]string{
+		"new",
+		"partial_fill",
+		"fill",
+		"canceled",
+		"expired",
+		"rejected",
+		"replaced",
+		"triggered",
+	}[e]
+}
+
+// ExecutionReport is the event of the order, the order is the copy of the order when the event happens
+type ExecutionReport struct {
+	// Seq is the sequence number of the execution report of the orderbook
+	Seq    uint64      `json:"seq"`
+	Type   ExecType    `json:"type"`
+	Time   time.Time   `json:"time"`
+	Status OrderStatus `json:"status"`
+	Order  Order       `json:"order"`
+	// Fill is the fill of the partial fill and fill report
+	Fill Fill `json:"fill"`
+	// Reason is the reason of the rejected report and the report of the self-trade prevention
+	Reason string `json:"reason"`
+}
+
+func (e ExecutionReport) String() string {
+	return fmt.Sprintf("execution report[%d]:[%s][%s]-<[order]: %s, [filled]: %d/%d, [reason]: %s>",
+		e.Seq, e.Type, e.Status, e.Order.ID, e.Order.FilledQty, e.Order.OriginalQty, e.Reason)
+}
+
+// ExecutionFilter selects the execution reports by the order id and the account, the empty field matches all
+type ExecutionFilter struct {
+	OrderID string
+	Account string
+}
+
+// match checks if the execution report passes the filter
+func (f ExecutionFilter) match(e ExecutionReport) bool {
+	if f.OrderID != "" && f.OrderID != e.Order.ID.String() {
+		return false
+	}
+	if f.Account != "" && f.Account != e.Order.Account {
+		return false
+	}
+	return true
+}
+
+// SubscribeExecutions returns the channel of the execution reports which pass the filter.
+// The channel is closed if the subscriber is too slow to receive the reports or it is unsubscribed.
+func (ob *OrderBook) SubscribeExecutions(filter ExecutionFilter, size int) <-chan ExecutionReport {
+	var ch <-chan ExecutionReport
+	if err := ob.exec(func() error {
+		ch = ob.executions.subscribe(size, filter.match)
+		return nil
+	}); err != nil {
+		return closedChan[ExecutionReport]()
+	}
+	return ch
+}
+
+// UnsubscribeExecutions unsubscribes the execution reports and closes the channel
+func (ob *OrderBook) UnsubscribeExecutions(ch <-chan ExecutionReport) {
+	ob.exec(func() error {
+		ob.executions.unsubscribe(ch)
+		return nil
+	})
+}
+
+// report publishes the execution report of the order and marks it as changed, it should be called with lock
+func (ob *OrderBook) report(typ ExecType, o *Order, status OrderStatus) {
+	ob.reportReason(typ, o, status, "")
+}
+
+// reportReason publishes the execution report of the order with the reason, it should be called with lock
+func (ob *OrderBook) reportReason(typ ExecType, o *Order, status OrderStatus, reason string) {
+	ob.touch(o.ID)
+	if ob.executions.Len() == 0 {
+		return
+	}
+	ob.execSeq++
+	ob.executions.publish(ExecutionReport{
+		Seq: ob.execSeq, Type: typ, Time: ob.now(), Status: status, Order: *o, Reason: reason,
+	})
+}
+
+// reportFill publishes the partial fill or fill report of the order, it should be called with lock
+func (ob *OrderBook) reportFill(o *Order, fill Fill) {
+	ob.touch(o.ID)
+	if ob.executions.Len() == 0 {
+		return
+	}
+	typ, status := ExecPartialFill, StatusPartiallyFilled
+	if o.Qty == 0 {
+		typ, status = ExecFill, StatusCompleted
+	}
+	ob.execSeq++
+	ob.executions.publish(ExecutionReport{Seq: ob.execSeq, Type: typ, Time: fill.Time, Status: status, Order: *o, Fill: fill})
+}
+
+// reject publishes the rejected report of the order and returns the reason, it should be called with lock
+func (ob *OrderBook) reject(o *Order, reason error) error {
+	ob.touch(o.ID)
+	if ob.executions.Len() > 0 {
+		ob.execSeq++
+		ob.executions.publish(ExecutionReport{
+			Seq: ob.execSeq, Type: ExecRejected, Time: ob.now(), Status: StatusRejected, Order: *o, Reason: reason.Error(),
+		})
+	}
+	return reason
+}
+
+// restingStatus returns the status of the resting order
+func restingStatus(o *Order) OrderStatus {
+	if o.untriggered() {
+		return StatusUntriggered
+	}
+	if o.FilledQty > 0 {
+		return StatusPartiallyFilled
+	}
+	return StatusPending
+}
@@ -0,0 +1,109 @@
+package orderbook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExecutionReports(t *testing.T) {
+
+	t.Log("start testing execution reports of the orderbook...")
+
+	clock := newTestClock()
+	ob, err := New(WithClock(clock.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice := ob.SubscribeExecutions(ExecutionFilter{Account: "alice"}, 100)
+	all := ob.SubscribeExecutions(ExecutionFilter{}, 100)
+
+	// alice: new
+	aid, err := ob.ProcessLimitOrder(Buy, 100, 10, WithAccount("alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// bob: new & fill, alice: partial fill
+	bid, err := ob.ProcessLimitOrder(Sell, 100, 4, WithAccount("bob"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	byOrder := ob.SubscribeExecutions(ExecutionFilter{OrderID: aid}, 100)
+	// alice: replaced
+	if err := ob.AmendOrder(aid, 100, 5); err != nil {
+		t.Fatal(err)
+	}
+	// bob: rejected
+	if _, err := ob.ProcessLimitOrder(Sell, 100, 10, WithAccount("bob"), WithTimeInForce(FOK)); err != ErrOrderNotFilled {
+		t.Fatal("the fill or kill order should be rejected, but got", err)
+	}
+	// alice: canceled
+	if err := ob.CancelOrder(aid); err != nil {
+		t.Fatal(err)
+	}
+	// alice: new & expired
+	gid, err := ob.ProcessLimitOrder(Buy, 90, 1, WithAccount("alice"), WithTimeInForce(GTD), WithExpireTime(clock.Now().Add(10*time.Millisecond)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Add(20 * time.Millisecond)
+	ob.cleanOldOrder()
+
+	type want struct {
+		typ    ExecType
+		id     string
+		status OrderStatus
+		filled int
+	}
+	check := func(name string, ch <-chan ExecutionReport, wants []want) {
+		for i, w := range wants {
+			var r ExecutionReport
+			select {
+			case r = <-ch:
+			default:
+				t.Fatalf("[%s] the report[%d] %s is missing", name, i, w.typ)
+			}
+			if r.Type != w.typ || r.Order.ID.String() != w.id || r.Status != w.status || r.Order.FilledQty != w.filled {
+				t.Fatalf("[%s] the report[%d] should be %v, but got %s", name, i, w, r)
+			}
+		}
+		select {
+		case r := <-ch:
+			t.Fatalf("[%s] unexpected report %s", name, r)
+		default:
+		}
+	}
+
+	check("alice", alice, []want{
+		{typ: ExecNew, id: aid, status: StatusPending},
+		{typ: ExecPartialFill, id: aid, status: StatusPartiallyFilled, filled: 4},
+		{typ: ExecReplaced, id: aid, status: StatusPartiallyFilled, filled: 4},
+		{typ: ExecCanceled, id: aid, status: StatusCanceled, filled: 4},
+		{typ: ExecNew, id: gid, status: StatusPending},
+		{typ: ExecExpired, id: gid, status: StatusCanceled},
+	})
+
+	check("order", byOrder, []want{
+		{typ: ExecReplaced, id: aid, status: StatusPartiallyFilled, filled: 4},
+		{typ: ExecCanceled, id: aid, status: StatusCanceled, filled: 4},
+	})
+
+	var rejected ExecutionReport
+	for r := range all {
+		if r.Type == ExecRejected {
+			rejected = r
+			break
+		}
+		if r.Type == ExecFill && (r.Order.ID.String() != bid || r.Fill.Qty != 4 || r.Fill.Price != 100) {
+			t.Fatal("wrong fill report", r)
+		}
+	}
+	if rejected.Status != StatusRejected || rejected.Order.Account != "bob" || rejected.Reason != ErrOrderNotFilled.Error() {
+		t.Fatal("wrong rejected report", rejected)
+	}
+
+	ob.UnsubscribeExecutions(alice)
+	if _, ok := <-alice; ok {
+		t.Fatal("the channel should be closed after unsubscribing")
+	}
+}
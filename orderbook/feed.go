@@ -0,0 +1,59 @@
+package orderbook
+
+// feed fans out the events to the subscribers, it is not thread-safe and is only used by the matching loop
+type feed[T any] struct {
+	// subs saves the channel and the filter of each subscriber
+	subs map[<-chan T]*subscriber[T]
+}
+
+// subscriber is the channel and the filter of the subscriber
+type subscriber[T any] struct {
+	ch     chan T
+	filter func(T) bool
+}
+
+// newFeed returns a feed without subscriber
+func newFeed[T any]() *feed[T] {
+	return &feed[T]{subs: make(map[<-chan T]*subscriber[T])}
+}
+
+// Len returns the number of the subscribers
+func (f *feed[T]) Len() int { return len(f.subs) }
+
+// subscribe returns the channel with the size of buffer for the events which pass the filter,
+// all the events are sent if the filter is nil
+func (f *feed[T]) subscribe(size int, filter func(T) bool) <-chan T {
+	sub := &subscriber[T]{ch: make(chan T, size), filter: filter}
+	f.subs[sub.ch] = sub
+	return sub.ch
+}
+
+// unsubscribe removes the subscriber and closes its channel
+func (f *feed[T]) unsubscribe(ch <-chan T) {
+	if sub, exist := f.subs[ch]; exist {
+		delete(f.subs, ch)
+		close(sub.ch)
+	}
+}
+
+// publish sends the event to the subscribers without blocking, the subscriber is removed and its
+// channel is closed if its buffer is full, so the slow subscriber never blocks the orderbook
+func (f *feed[T]) publish(ev T) {
+	for ch, sub := range f.subs {
+		if sub.filter != nil && !sub.filter(ev) {
+			continue
+		}
+		select {
+		case sub.ch <- ev:
+		default:
+			f.unsubscribe(ch)
+		}
+	}
+}
+
+// closedChan returns the closed channel, it is returned if the orderbook can not be subscribed
+func closedChan[T any]() <-chan T {
+	ch := make(chan T)
+	close(ch)
+	return ch
+}
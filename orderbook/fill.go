@@ -0,0 +1,47 @@
+package orderbook
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Fill is the record of the execution between the maker order and the taker order
+type Fill struct {
+	ID           uuid.UUID `json:"id"`
+	MakerOrderID uuid.UUID `json:"maker_order_id"`
+	TakerOrderID uuid.UUID `json:"taker_order_id"`
+	Price        int       `json:"price"`
+	Qty          int       `json:"quantity"`
+	// Aggressor is the side of the taker order
+	Aggressor Side      `json:"aggressor_side"`
+	Time      time.Time `json:"time"`
+}
+
+func (f Fill) String() string {
+	// fill[id]:[aggressor side]-<maker, taker, price, qty, time>
+	return fmt.Sprintf(
+		"fill[%s]:[%s]-<[maker]: %s, [taker]: %s, [price]: %d, [qty]: %d, [time]: %s>\n",
+		f.ID.String(), f.Aggressor.String(), f.MakerOrderID.String(), f.TakerOrderID.String(), f.Price, f.Qty, f.Time,
+	)
+}
+
+// newFill returns the fill of the maker and the taker order with the price of the maker order, it is made before
+// the maker is filled. The fill is at the time of the taker which is the time of the command, and its id is derived
+// from the ids of the orders and the filled qty of the maker, so the replayed commands make the same fills.
+func newFill(maker, taker *Order, qty int) Fill {
+	var name [len(uuid.UUID{}) + 8]byte
+	copy(name[:], taker.ID[:])
+	binary.BigEndian.PutUint64(name[len(uuid.UUID{}):], uint64(maker.FilledQty))
+	return Fill{
+		ID:           uuid.NewSHA1(maker.ID, name[:]),
+		MakerOrderID: maker.ID,
+		TakerOrderID: taker.ID,
+		Price:        maker.Price,
+		Qty:          qty,
+		Aggressor:    taker.Side,
+		Time:         taker.Time,
+	}
+}
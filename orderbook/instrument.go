@@ -0,0 +1,175 @@
+package orderbook
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// maxScale is the max number of the decimal places of the prices and qty
+const maxScale = 18
+
+// Instrument is the spec. of the prices and qty of the orderbook. The prices and qty of the orders are the
+// fixed-point numbers in the smallest units of the instrument, e.g. the price 101.25 is 10125 with the price scale 2
+// and the qty 0.001 is 1 with the qty scale 3.
+type Instrument struct {
+	// PriceScale & QtyScale are the number of the decimal places of the prices and qty
+	PriceScale int `json:"price_scale"`
+	QtyScale   int `json:"qty_scale"`
+	// TickSize is the min. change of the price, LotSize is the min. change of the qty, in the smallest units
+	TickSize int `json:"tick_size"`
+	LotSize  int `json:"lot_size"`
+	// MinQty & MaxQty are the limits of the qty of the order in the smallest units, MaxQty is unlimited if it is 0
+	MinQty int `json:"min_qty"`
+	MaxQty int `json:"max_qty"`
+	// Base & Quote are the assets of the instrument which are settled by the ledger, the orders buy or sell the
+	// base asset with the quote asset
+	Base  string `json:"base"`
+	Quote string `json:"quote"`
+}
+
+// DefaultInstrument is the instrument of the integer prices and qty without any limit
+var DefaultInstrument = Instrument{TickSize: 1, LotSize: 1, MinQty: 1}
+
+func (i Instrument) String() string {
+	return fmt.Sprintf("instrument-<[price scale]: %d, [qty scale]: %d, [tick]: %s, [lot]: %s, [min qty]: %s, [max qty]: %s>",
+		i.PriceScale, i.QtyScale, i.FormatPrice(i.TickSize), i.FormatQty(i.LotSize), i.FormatQty(i.MinQty), i.FormatQty(i.MaxQty))
+}
+
+// Validate checks if the spec. of the instrument is valid
+func (i Instrument) Validate() error {
+	switch {
+	case i.PriceScale < 0 || i.PriceScale > maxScale || i.QtyScale < 0 || i.QtyScale > maxScale:
+		return fmt.Errorf("%w: the scale should be in [0, %d]", ErrBadInstrument, maxScale)
+	case i.TickSize < 1 || i.LotSize < 1:
+		return fmt.Errorf("%w: the tick size and lot size should be greater than 0", ErrBadInstrument)
+	case i.MinQty < 1 || i.MinQty%i.LotSize != 0:
+		return fmt.Errorf("%w: the min qty should be a positive multiple of the lot size", ErrBadInstrument)
+	case i.MaxQty != 0 && (i.MaxQty < i.MinQty || i.MaxQty%i.LotSize != 0):
+		return fmt.Errorf("%w: the max qty should be a multiple of the lot size and not less than the min qty", ErrBadInstrument)
+	case len(i.Base) > 0 && i.Base == i.Quote:
+		return fmt.Errorf("%w: the base and quote assets should be different", ErrBadInstrument)
+	}
+	return nil
+}
+
+// checkPrice checks if the price is on the tick
+func (i Instrument) checkPrice(price int) error {
+	if price < 1 {
+		return ErrBadOrderPrice
+	}
+	if price%i.TickSize != 0 {
+		return fmt.Errorf("%w: %s", ErrBadTickSize, i.FormatPrice(price))
+	}
+	return nil
+}
+
+// checkQty checks if the qty is on the lot and in the limits
+func (i Instrument) checkQty(qty int) error {
+	switch {
+	case qty < 1:
+		return ErrBadOrderQty
+	case qty%i.LotSize != 0:
+		return fmt.Errorf("%w: %s", ErrBadLotSize, i.FormatQty(qty))
+	case qty < i.MinQty:
+		return fmt.Errorf("%w: %s < %s", ErrOrderQtyTooSmall, i.FormatQty(qty), i.FormatQty(i.MinQty))
+	case i.MaxQty > 0 && qty > i.MaxQty:
+		return fmt.Errorf("%w: %s > %s", ErrOrderQtyTooLarge, i.FormatQty(qty), i.FormatQty(i.MaxQty))
+	}
+	return nil
+}
+
+// checkOrder checks the price, stop price, qty and display qty of the order, the price of the market order is not checked
+func (i Instrument) checkOrder(o *Order) error {
+	if o.PriceMode != Market {
+		if err := i.checkPrice(o.Price); err != nil {
+			return err
+		}
+	}
+	if o.StopPrice > 0 && o.StopPrice%i.TickSize != 0 {
+		return fmt.Errorf("%w: %s", ErrBadTickSize, i.FormatPrice(o.StopPrice))
+	}
+	if o.DisplayQty > 0 && o.DisplayQty%i.LotSize != 0 {
+		return fmt.Errorf("%w: %s", ErrBadLotSize, i.FormatQty(o.DisplayQty))
+	}
+	return i.checkQty(o.Qty)
+}
+
+// ParsePrice parses the decimal price, e.g. "101.25", to the smallest units of the instrument
+func (i Instrument) ParsePrice(s string) (int, error) {
+	return ParseDecimal(s, i.PriceScale)
+}
+
+// ParseQty parses the decimal qty, e.g. "0.001", to the smallest units of the instrument
+func (i Instrument) ParseQty(s string) (int, error) {
+	return ParseDecimal(s, i.QtyScale)
+}
+
+// FormatPrice formats the price in the smallest units of the instrument to the decimal
+func (i Instrument) FormatPrice(price int) string {
+	return FormatDecimal(int64(price), i.PriceScale)
+}
+
+// FormatQty formats the qty in the smallest units of the instrument to the decimal
+func (i Instrument) FormatQty(qty int) string {
+	return FormatDecimal(int64(qty), i.QtyScale)
+}
+
+// ParseDecimal parses the decimal string to the integer of the smallest units of the scale, e.g. "101.25" is 10125
+// with the scale 2. The decimal with more decimal places than the scale is rejected.
+func ParseDecimal(s string, scale int) (int, error) {
+	if scale < 0 || scale > maxScale {
+		return 0, fmt.Errorf("%w: unsupported scale %d", ErrBadDecimal, scale)
+	}
+
+	str := s
+	neg := strings.HasPrefix(str, "-")
+	if neg || strings.HasPrefix(str, "+") {
+		str = str[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	if len(intPart)+len(fracPart) == 0 {
+		return 0, fmt.Errorf("%w: %q", ErrBadDecimal, s)
+	}
+	// the trailing zeros do not change the value
+	fracPart = strings.TrimRight(fracPart, "0")
+	if len(fracPart) > scale {
+		return 0, fmt.Errorf("%w: %q has more than %d decimal places", ErrBadDecimal, s, scale)
+	}
+
+	var v uint64
+	digits := intPart + fracPart + strings.Repeat("0", scale-len(fracPart))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("%w: %q", ErrBadDecimal, s)
+		}
+		if v > (math.MaxInt64-uint64(c-'0'))/10 {
+			return 0, fmt.Errorf("%w: %q is out of range", ErrBadDecimal, s)
+		}
+		v = v*10 + uint64(c-'0')
+	}
+	if v > math.MaxInt {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrBadDecimal, s)
+	}
+
+	if neg {
+		return -int(v), nil
+	}
+	return int(v), nil
+}
+
+// FormatDecimal formats the integer of the smallest units of the scale to the decimal string, e.g. 10125 is
+// "101.25" with the scale 2
+func FormatDecimal(v int64, scale int) string {
+	if scale <= 0 {
+		return fmt.Sprintf("%d", v)
+	}
+
+	sign := ""
+	u := uint64(v)
+	if v < 0 {
+		sign, u = "-", uint64(-v)
+	}
+	digits := fmt.Sprintf("%0*d", scale+1, u)
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
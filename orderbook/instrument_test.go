@@ -0,0 +1,128 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+
+	t.Log("start testing the fixed-point decimals...")
+
+	testcases := []struct {
+		s     string
+		scale int
+		want  int
+		err   error
+	}{
+		{s: "101.25", scale: 2, want: 10125},
+		{s: "101.250", scale: 2, want: 10125},
+		{s: "101", scale: 2, want: 10100},
+		{s: ".5", scale: 1, want: 5},
+		{s: "0.001", scale: 3, want: 1},
+		{s: "-1.5", scale: 1, want: -15},
+		{s: "42", scale: 0, want: 42},
+		{s: "101.255", scale: 2, err: ErrBadDecimal},
+		{s: "1.2.3", scale: 2, err: ErrBadDecimal},
+		{s: "abc", scale: 2, err: ErrBadDecimal},
+		{s: "", scale: 2, err: ErrBadDecimal},
+		{s: ".", scale: 2, err: ErrBadDecimal},
+		{s: "99999999999999999999", scale: 0, err: ErrBadDecimal},
+	}
+	for _, tc := range testcases {
+		got, err := ParseDecimal(tc.s, tc.scale)
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("%q should be rejected by %v, but got %v", tc.s, tc.err, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Fatalf("%q should be %d, but got %d (%v)", tc.s, tc.want, got, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		v     int64
+		scale int
+		want  string
+	}{
+		{v: 10125, scale: 2, want: "101.25"},
+		{v: 1, scale: 3, want: "0.001"},
+		{v: -15, scale: 1, want: "-1.5"},
+		{v: 42, scale: 0, want: "42"},
+	} {
+		if got := FormatDecimal(tc.v, tc.scale); got != tc.want {
+			t.Fatalf("%d with the scale %d should be %q, but got %q", tc.v, tc.scale, tc.want, got)
+		}
+	}
+	t.Log("... Passed")
+}
+
+func TestInstrument(t *testing.T) {
+
+	t.Log("start testing the orders of the instrument...")
+
+	// the price 101.25 with the tick 0.25 and the qty 0.001 BTC with the lot 0.001 and the max 10 BTC
+	inst := Instrument{PriceScale: 2, QtyScale: 3, TickSize: 25, LotSize: 1, MinQty: 1, MaxQty: 10000}
+	if _, err := New(WithInstrumentSpec(Instrument{TickSize: 0, LotSize: 1, MinQty: 1})); !errors.Is(err, ErrBadInstrument) {
+		t.Fatal("the bad instrument should be rejected, but got", err)
+	}
+	ob, err := New(WithInstrumentSpec(inst))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	price, _ := inst.ParsePrice("101.25")
+	qty, _ := inst.ParseQty("0.001")
+	id, err := ob.ProcessLimitOrder(Buy, price, qty)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		price, qty string
+		err        error
+	}{
+		{price: "101.3", qty: "1", err: ErrBadTickSize},
+		{price: "101.5", qty: "10.001", err: ErrOrderQtyTooLarge},
+		{price: "101.5", qty: "0", err: ErrBadOrderQty},
+		{price: "0", qty: "1", err: ErrBadOrderPrice},
+	}
+	for _, tc := range testcases {
+		p, _ := inst.ParsePrice(tc.price)
+		q, _ := inst.ParseQty(tc.qty)
+		if _, err := ob.ProcessLimitOrder(Sell, p, q); !errors.Is(err, tc.err) {
+			t.Fatalf("the order %s@%s should be rejected by %v, but got %v", tc.qty, tc.price, tc.err, err)
+		}
+		if _, err := NewOrder(Sell, p, q, WithInstrument(inst)); !errors.Is(err, tc.err) {
+			t.Fatalf("the new order %s@%s should be rejected by %v, but got %v", tc.qty, tc.price, tc.err, err)
+		}
+	}
+
+	// the lot and min qty
+	lotInst := Instrument{TickSize: 1, LotSize: 5, MinQty: 10}
+	if _, err := NewOrder(Buy, 100, 12, WithInstrument(lotInst)); !errors.Is(err, ErrBadLotSize) {
+		t.Fatal("the qty off the lot should be rejected, but got", err)
+	}
+	if _, err := NewOrder(Buy, 100, 5, WithInstrument(lotInst)); !errors.Is(err, ErrOrderQtyTooSmall) {
+		t.Fatal("the qty less than the min qty should be rejected, but got", err)
+	}
+
+	// the amended price should be on the tick and the price of the market order is not checked
+	if err := ob.AmendOrder(id, price+1, qty); !errors.Is(err, ErrBadTickSize) {
+		t.Fatal("the amended price off the tick should be rejected, but got", err)
+	}
+	if _, err := ob.ProcessMarketOrder(Sell, qty); err != nil {
+		t.Fatal(err)
+	}
+	var order Order
+	if status, err := ob.GetOrder(id, &order); err != nil || status != StatusCompleted {
+		t.Fatal("the order should be completed", status, err)
+	}
+	if got := inst.FormatPrice(int(order.AvgPrice)); got != "101.25" {
+		t.Fatalf("the average price should be 101.25, but got %s", got)
+	}
+	t.Log("... Passed")
+}
@@ -0,0 +1,368 @@
+package orderbook
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// SyncPolicy is when the journal is flushed to the disk by fsync
+type SyncPolicy int
+
+const (
+	// SyncAlways flushes every entry before the command is applied, no accepted command is lost by a crash
+	SyncAlways SyncPolicy = iota
+	// SyncInterval flushes the entries periodically, the commands in the last interval may be lost by a crash of the OS
+	SyncInterval
+	// SyncNone leaves the flushing to the OS
+	SyncNone
+)
+
+func (s SyncPolicy) String() string {
+	if s < SyncAlways || s > SyncNone {
+		return "unknown"
+	}
+	return [...]string{
+		"always",
+		"interval",
+		"none",
+	}[s]
+}
+
+// ParseSyncPolicy returns the sync policy by its name
+func ParseSyncPolicy(name string) (SyncPolicy, error) {
+	for _, s := range []SyncPolicy{SyncAlways, SyncInterval, SyncNone} {
+		if s.String() == name {
+			return s, nil
+		}
+	}
+	return SyncAlways, ErrBadSyncPolicy
+}
+
+// JournalOption is an option type for Journal
+type JournalOption func(j *Journal) error
+
+// WithSyncPolicy is an option for the sync policy of the journal
+func WithSyncPolicy(policy SyncPolicy) JournalOption {
+	return func(j *Journal) error {
+		if policy < SyncAlways || policy > SyncNone {
+			return ErrBadSyncPolicy
+		}
+		j.policy = policy
+		return nil
+	}
+}
+
+// WithSyncInterval is an option for the interval of the SyncInterval policy
+func WithSyncInterval(interval time.Duration) JournalOption {
+	return func(j *Journal) error {
+		if interval <= 0 {
+			return ErrBadSyncInterval
+		}
+		j.interval = interval
+		return nil
+	}
+}
+
+// journalCmd is the type of the command in the journal
+type journalCmd string
+
+const (
+	journalSubmit journalCmd = "submit"
+	journalCancel journalCmd = "cancel"
+	journalAmend  journalCmd = "amend"
+	journalExpire journalCmd = "expire"
+	// journalReject is the triggered stop order which is rejected at its activation, it follows the command
+	// which triggers the stop
+	journalReject journalCmd = "reject"
+
+	// the commands of the journal of the ledger
+	journalDeposit  journalCmd = "deposit"
+	journalWithdraw journalCmd = "withdraw"
+	// journalHold sets the funds which are held by the order
+	journalHold journalCmd = "hold"
+	// journalSettle pays the held funds of the order for a fill and receives the other asset
+	journalSettle journalCmd = "settle"
+)
+
+// journalEntry is the command which changes the orderbook or the ledger, it is one line of json in the journal
+type journalEntry struct {
+	// Seq is the sequence number of the command, it is used to skip the commands which are in the snapshot
+	Seq  uint64     `json:"seq"`
+	Type journalCmd `json:"type"`
+	// Order is the new order of the submit command
+	Order *Order `json:"order,omitempty"`
+	// ID is the order id of the cancel, amend, expire, reject, hold and settle command
+	ID string `json:"id,omitempty"`
+	// Reason is the reason of the reject command
+	Reason string `json:"reason,omitempty"`
+	// Price, Qty and Time are the new price, qty and priority of the amended order
+	Price int       `json:"price,omitempty"`
+	Qty   int       `json:"quantity,omitempty"`
+	Time  time.Time `json:"time,omitempty"`
+	// Account, Asset and Amount are the funds of the ledger commands, Received and ReceivedAmount are the funds
+	// which are received by the settle command
+	Account        string `json:"account,omitempty"`
+	Asset          string `json:"asset,omitempty"`
+	Amount         int    `json:"amount,omitempty"`
+	Received       string `json:"received,omitempty"`
+	ReceivedAmount int    `json:"received_amount,omitempty"`
+}
+
+// Journal is the append-only log of the commands of the orderbook, the commands are written before they are applied
+type Journal struct {
+	sync.Mutex
+	path     string
+	f        *os.File
+	policy   SyncPolicy
+	interval time.Duration
+	// dirty is true if there are entries which are not flushed
+	dirty bool
+	// stop stops the flusher of the SyncInterval policy
+	stop chan struct{}
+	done chan struct{}
+}
+
+// OpenJournal opens or creates the journal at the path, the new entries are appended to the end of it
+func OpenJournal(path string, opts ...JournalOption) (*Journal, error) {
+	j := &Journal{path: path, policy: SyncAlways, interval: time.Second}
+	for _, opt := range opts {
+		if err := opt(j); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	j.f = f
+
+	if j.policy == SyncInterval {
+		j.stop, j.done = make(chan struct{}), make(chan struct{})
+		go j.flusher()
+	}
+	return j, nil
+}
+
+// Path returns the path of the journal
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// flusher flushes the journal periodically until the journal is closed
+func (j *Journal) flusher() {
+	defer close(j.done)
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := j.Sync(); err != nil {
+				log.Println("journal: failed to sync:", err)
+			}
+		case <-j.stop:
+			return
+		}
+	}
+}
+
+// write appends the entry to the journal and flushes it by the sync policy
+func (j *Journal) write(e journalEntry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	j.Lock()
+	defer j.Unlock()
+	if _, err := j.f.Write(b); err != nil {
+		return err
+	}
+	if j.policy == SyncAlways {
+		return j.f.Sync()
+	}
+	j.dirty = true
+	return nil
+}
+
+// Sync flushes the entries to the disk
+func (j *Journal) Sync() error {
+	j.Lock()
+	defer j.Unlock()
+	if !j.dirty {
+		return nil
+	}
+	j.dirty = false
+	return j.f.Sync()
+}
+
+// truncate removes all the entries of the journal
+func (j *Journal) truncate() error {
+	j.Lock()
+	defer j.Unlock()
+	if err := j.f.Truncate(0); err != nil {
+		return err
+	}
+	j.dirty = false
+	return j.f.Sync()
+}
+
+// Close flushes the entries and closes the journal
+func (j *Journal) Close() error {
+	if j.stop != nil {
+		close(j.stop)
+		<-j.done
+	}
+	j.Lock()
+	defer j.Unlock()
+	if err := j.f.Sync(); err != nil {
+		j.f.Close()
+		return err
+	}
+	return j.f.Close()
+}
+
+// entries reads the entries from the beginning of the journal, the torn entry at the end of the journal
+// which is written partially by a crash is truncated
+func (j *Journal) entries() ([]journalEntry, error) {
+	j.Lock()
+	defer j.Unlock()
+
+	f, err := os.Open(j.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := make([]journalEntry, 0)
+	r := bufio.NewReader(f)
+	offset := int64(0)
+	for line := 1; ; line++ {
+		b, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			if len(bytes.TrimSpace(b)) > 0 {
+				log.Printf("journal: truncate the torn entry at line %d of %s\n", line, j.path)
+				return entries, j.f.Truncate(offset)
+			}
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var e journalEntry
+		if err := json.Unmarshal(b, &e); err != nil {
+			return nil, fmt.Errorf("%w: line %d of %s: %v", ErrBadJournal, line, j.path, err)
+		}
+		entries = append(entries, e)
+		offset += int64(len(b))
+	}
+}
+
+// WithJournal is an option for the journal of the orderbook, the commands which change the orderbook are
+// written to the journal before they are applied
+func WithJournal(j *Journal) Option {
+	return func(ob *OrderBook) error {
+		if j == nil {
+			return errors.New("the journal is empty")
+		}
+		ob.journal = j
+		return nil
+	}
+}
+
+// writeJournal writes the command to the journal if the orderbook has one, it is called by the matching loop
+func (ob *OrderBook) writeJournal(e journalEntry) error {
+	if ob.journal == nil {
+		return nil
+	}
+	e.Seq = ob.journalSeq + 1
+	if err := ob.journal.write(e); err != nil {
+		return err
+	}
+	ob.journalSeq = e.Seq
+	return nil
+}
+
+// Replay rebuilds the orderbook by applying the commands in its journal, it should be called before the
+// orderbook accepts any command and after the snapshot is restored, the commands in the snapshot are skipped.
+// The orders are rebuilt with their ids and time priorities.
+func (ob *OrderBook) Replay() error {
+	if ob.journal == nil {
+		return nil
+	}
+	entries, err := ob.journal.entries()
+	if err != nil {
+		return err
+	}
+
+	// the stop orders which are rejected at their activation are rejected by the commands which trigger them,
+	// the rejection may depend on the ledger which is not changed by the replay
+	rejected := make(map[string]string)
+	for _, e := range entries {
+		if e.Type == journalReject && e.Seq > ob.journalSeq {
+			rejected[e.ID] = e.Reason
+		}
+	}
+
+	return ob.exec(func() error {
+		// the ledger is rebuilt by its own journal, so it is not changed by the replayed commands
+		ob.replaying, ob.rejected = true, rejected
+		defer func() { ob.replaying, ob.rejected = false, nil }()
+		for i, e := range entries {
+			if e.Seq <= ob.journalSeq {
+				continue
+			}
+			if err := ob.apply(e); err != nil {
+				return fmt.Errorf("%w: entry %d: %v", ErrBadJournal, i+1, err)
+			}
+			ob.journalSeq = e.Seq
+		}
+		return nil
+	})
+}
+
+// apply applies the command of the journal without lock
+func (ob *OrderBook) apply(e journalEntry) error {
+	switch e.Type {
+	case journalSubmit:
+		if e.Order == nil {
+			return errors.New("the order of the submit command is empty")
+		}
+		o := *e.Order
+		return ob.submit(&o)
+	case journalCancel, journalExpire:
+		q, order := ob.findOrder(e.ID)
+		if order == nil {
+			return ErrDataNotFound
+		}
+		if e.Type == journalCancel {
+			ob.cancel(q, order, ExecCanceled)
+		} else {
+			ob.cancel(q, order, ExecExpired)
+		}
+		return nil
+	case journalAmend:
+		q, order := ob.findOrder(e.ID)
+		if order == nil {
+			return ErrDataNotFound
+		}
+		return ob.amend(q, order, e.Price, e.Qty, e.Time)
+	case journalReject:
+		// the stop order is already rejected by the command which triggers it
+		if _, exist := ob.Canceled[e.ID]; !exist {
+			return ErrDataNotFound
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown command %q", e.Type)
+}
@@ -0,0 +1,228 @@
+package orderbook
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJournalReplay(t *testing.T) {
+
+	t.Log("start testing the replay of the journal...")
+
+	path := filepath.Join(t.TempDir(), "default.journal")
+
+	for _, policy := range []SyncPolicy{SyncAlways, SyncInterval, SyncNone} {
+		os.Remove(path)
+
+		j, err := OpenJournal(path, WithSyncPolicy(policy), WithSyncInterval(time.Millisecond))
+		if err != nil {
+			t.Fatal(err)
+		}
+		clock := newTestClock()
+		ob, err := New(WithJournal(j), WithClock(clock.Now))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 5; i++ {
+			if _, err := ob.ProcessLimitOrder(Buy, 100-i, 10+i); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := ob.ProcessLimitOrder(Sell, 105+i, 10+i); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// partially fills the bid at 100
+		if _, err := ob.ProcessLimitOrder(Sell, 100, 4); err != nil {
+			t.Fatal(err)
+		}
+		// the rejected order is not journaled
+		if _, err := ob.ProcessLimitOrder(Sell, 90, 1000, WithTimeInForce(FOK)); err != ErrOrderNotFilled {
+			t.Fatal("the fill or kill order should be rejected, but got", err)
+		}
+		if _, err := ob.ProcessMarketOrder(Buy, 5); err != nil {
+			t.Fatal(err)
+		}
+		bids := ob.GetBids()
+		asks := ob.GetAsks()
+		if err := ob.AmendOrder(bids[1].ID.String(), 101, 30); err != nil {
+			t.Fatal(err)
+		}
+		if err := ob.AmendOrder(asks[1].ID.String(), asks[1].Price, 3); err != nil {
+			t.Fatal(err)
+		}
+		if err := ob.CancelOrder(bids[2].ID.String()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ob.ProcessLimitOrder(Buy, 95, 1, WithTimeInForce(GTD), WithExpireTime(clock.Now().Add(10*time.Millisecond))); err != nil {
+			t.Fatal(err)
+		}
+		clock.Add(20 * time.Millisecond)
+		ob.cleanOldOrder()
+
+		if err := j.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		// replay the journal into a new orderbook
+		j, err = OpenJournal(path, WithSyncPolicy(policy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		replayed, err := New(WithJournal(j))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := replayed.Replay(); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, side := range []Side{Buy, Sell} {
+			want, got := ob.own(side).orders(), replayed.own(side).orders()
+			if len(want) != len(got) {
+				t.Fatalf("[%s] the number of the orders should be %d, but got %d", policy, len(want), len(got))
+			}
+			for i := range want {
+				if want[i].ID != got[i].ID || want[i].Price != got[i].Price || want[i].Qty != got[i].Qty ||
+					want[i].FilledQty != got[i].FilledQty || !want[i].Time.Equal(got[i].Time) {
+					t.Fatalf("[%s] the order[%d] should be %s, but got %s", policy, i, want[i], got[i])
+				}
+			}
+		}
+		if len(ob.Done) != len(replayed.Done) || len(ob.Canceled) != len(replayed.Canceled) || len(ob.Fills) != len(replayed.Fills) {
+			t.Fatalf("[%s] the history is not rebuilt, done: %d/%d, canceled: %d/%d, fills: %d/%d", policy,
+				len(replayed.Done), len(ob.Done), len(replayed.Canceled), len(ob.Canceled), len(replayed.Fills), len(ob.Fills))
+		}
+		for id := range ob.Canceled {
+			if _, exist := replayed.Canceled[id]; !exist {
+				t.Fatalf("[%s] the canceled order %s is not rebuilt", policy, id)
+			}
+		}
+		// the replayed fills have the same ids and times
+		for i := range ob.Fills {
+			if want, got := ob.Fills[i], replayed.Fills[i]; want.ID != got.ID || !want.Time.Equal(got.Time) {
+				t.Fatalf("[%s] the fill[%d] should be %s, but got %s", policy, i, want, got)
+			}
+		}
+
+		// the replayed orderbook keeps journaling
+		if _, err := replayed.ProcessLimitOrder(Buy, 80, 1); err != nil {
+			t.Fatal(err)
+		}
+		if err := j.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestJournalTornEntry(t *testing.T) {
+
+	t.Log("start testing the torn entry of the journal...")
+
+	path := filepath.Join(t.TempDir(), "default.journal")
+	j, err := OpenJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ob, err := New(WithJournal(j))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 10); err != nil {
+		t.Fatal(err)
+	}
+	j.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// crash in the middle of writing the entry
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"type":"submit","order":{"id":`)
+	f.Close()
+
+	j, err = OpenJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	replayed, err := New(WithJournal(j))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := replayed.Replay(); err != nil {
+		t.Fatal(err)
+	}
+	if bids := replayed.GetBids(); len(bids) != 1 || bids[0].Qty != 10 {
+		t.Fatal("the bid should be rebuilt", bids)
+	}
+	if truncated, err := os.Stat(path); err != nil || truncated.Size() != info.Size() {
+		t.Fatal("the torn entry should be truncated", err)
+	}
+}
+
+func TestJournalRejectedStop(t *testing.T) {
+
+	t.Log("start testing the replay of the stop order which is rejected at its activation...")
+
+	path := filepath.Join(t.TempDir(), "default.journal")
+	inst := DefaultInstrument
+	inst.Base, inst.Quote = "BTC", "USD"
+	open := func() (*Journal, *OrderBook) {
+		j, err := OpenJournal(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		l, err := NewLedger()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ob, err := New(WithJournal(j), WithLedger(l), WithInstrumentSpec(inst))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return j, ob
+	}
+
+	j, ob := open()
+	ob.ledger.Deposit("alice", "USD", 1000)
+	ob.ledger.Deposit("bob", "BTC", 100)
+	ob.ledger.Deposit("carol", "USD", 100)
+	if _, err := ob.ProcessLimitOrder(Sell, 100, 20, WithAccount("bob")); err != nil {
+		t.Fatal(err)
+	}
+	stop, err := ob.ProcessLimitOrder(Buy, 100, 10, WithAccount("alice"), WithStopPrice(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the funds of the stop order are held by another order before it is triggered
+	if _, err := ob.ProcessLimitOrder(Buy, 90, 5, WithAccount("alice")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 1, WithAccount("carol")); err != nil {
+		t.Fatal(err)
+	}
+	var order Order
+	if status, _ := ob.GetOrder(stop, &order); status != StatusCanceled || order.FilledQty != 0 {
+		t.Fatal("the stop order should be rejected for insufficient funds", status, order)
+	}
+	j.Close()
+
+	// the replayed orderbook has no funds, but the stop order is rejected by the journal
+	j, replayed := open()
+	defer j.Close()
+	if err := replayed.Replay(); err != nil {
+		t.Fatal(err)
+	}
+	sameBook(t, ob, replayed)
+	if status, _ := replayed.GetOrder(stop, &order); status != StatusCanceled || order.FilledQty != 0 {
+		t.Fatal("the replayed stop order should be rejected", status, order)
+	}
+}
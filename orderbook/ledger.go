@@ -0,0 +1,623 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Balance is the balance of an asset of an account in the smallest units of the asset, Held is reserved by the
+// resting orders and Available can be used by the new orders or withdrawn
+type Balance struct {
+	Asset     string `json:"asset"`
+	Available int    `json:"available"`
+	Held      int    `json:"held"`
+}
+
+func (b Balance) String() string {
+	return fmt.Sprintf("balance-<[asset]: %s, [available]: %d, [held]: %d>", b.Asset, b.Available, b.Held)
+}
+
+// hold is the funds which are reserved by an order
+type hold struct {
+	account string
+	asset   string
+	amount  int
+}
+
+// Ledger is the balances of the accounts which are shared by the orderbooks. The orders of the orderbook with the
+// ledger should be owned by the accounts, the funds of the orders are held before they trade and the balances are
+// settled by each fill. The base asset is in the smallest units of the qty and the quote asset is in the smallest
+// units of the price * qty of the instruments.
+type Ledger struct {
+	sync.Mutex
+	balances map[string]map[string]*Balance
+	holds    map[uuid.UUID]*hold
+	// scales are the number of the decimal places of the assets
+	scales map[string]int
+
+	// journal is the journal of the changes of the balances and the held funds, journalSeq is the sequence
+	// number of the last change
+	journal    *Journal
+	journalSeq uint64
+	// snapshotPath is the path of the snapshot of the ledger which is taken every snapshotInterval
+	snapshotPath     string
+	snapshotInterval time.Duration
+}
+
+// LedgerOption is an option type for Ledger
+type LedgerOption func(l *Ledger) error
+
+// WithLedgerJournal is an option for the journal of the ledger, the deposits, withdrawals, held funds and
+// settlements are written to the journal before they are applied
+func WithLedgerJournal(j *Journal) LedgerOption {
+	return func(l *Ledger) error {
+		if j == nil {
+			return errors.New("the journal is empty")
+		}
+		l.journal = j
+		return nil
+	}
+}
+
+// WithLedgerSnapshot is an option for the path of the snapshot of the ledger and the interval of taking snapshots,
+// the snapshot is only taken by SaveSnapshot if the interval is 0
+func WithLedgerSnapshot(path string, interval time.Duration) LedgerOption {
+	return func(l *Ledger) error {
+		if len(path) == 0 {
+			return errors.New("the path of the snapshot is empty")
+		}
+		if interval < 0 {
+			return ErrBadSnapshotInterval
+		}
+		l.snapshotPath = path
+		l.snapshotInterval = interval
+		return nil
+	}
+}
+
+// NewLedger returns an empty ledger with the options
+func NewLedger(opts ...LedgerOption) (*Ledger, error) {
+	l := &Ledger{
+		balances: make(map[string]map[string]*Balance),
+		holds:    make(map[uuid.UUID]*hold),
+		scales:   make(map[string]int),
+	}
+	for _, opt := range opts {
+		if err := opt(l); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// WithLedger is an option for the ledger of the orderbook, the instrument of the orderbook should have the base and
+// quote assets. The ledger has its own journal and snapshot, so it is not changed by the replayed orders.
+func WithLedger(l *Ledger) Option {
+	return func(ob *OrderBook) error {
+		if l == nil {
+			return ErrBadLedger
+		}
+		ob.ledger = l
+		return nil
+	}
+}
+
+// register registers the scales of the assets of the instrument, the asset should have the same scale in all the
+// instruments
+func (l *Ledger) register(inst Instrument) error {
+	if len(inst.Base) == 0 || len(inst.Quote) == 0 {
+		return fmt.Errorf("%w: the base and quote assets are required by the ledger", ErrBadInstrument)
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	scales := map[string]int{inst.Base: inst.QtyScale, inst.Quote: inst.PriceScale + inst.QtyScale}
+	for asset, scale := range scales {
+		if s, exist := l.scales[asset]; exist && s != scale {
+			return fmt.Errorf("%w: the scale of %s should be %d", ErrBadInstrument, asset, s)
+		}
+	}
+	for asset, scale := range scales {
+		l.scales[asset] = scale
+	}
+	return nil
+}
+
+// Scale returns the number of the decimal places of the asset
+func (l *Ledger) Scale(asset string) (int, error) {
+	l.Lock()
+	defer l.Unlock()
+	scale, exist := l.scales[asset]
+	if !exist {
+		return 0, ErrAssetNotFound
+	}
+	return scale, nil
+}
+
+// Deposit adds the amount to the available balance of the asset of the account
+func (l *Ledger) Deposit(account, asset string, amount int) (Balance, error) {
+	if len(account) == 0 {
+		return Balance{}, ErrAccountRequired
+	}
+	if amount < 1 {
+		return Balance{}, ErrBadAmount
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	if _, exist := l.scales[asset]; !exist {
+		return Balance{}, ErrAssetNotFound
+	}
+	if err := l.writeJournal(journalEntry{Type: journalDeposit, Account: account, Asset: asset, Amount: amount}); err != nil {
+		return Balance{}, err
+	}
+	b := l.balance(account, asset)
+	b.Available += amount
+	return *b, nil
+}
+
+// Withdraw subtracts the amount from the available balance of the asset of the account, the held funds can not be
+// withdrawn
+func (l *Ledger) Withdraw(account, asset string, amount int) (Balance, error) {
+	if len(account) == 0 {
+		return Balance{}, ErrAccountRequired
+	}
+	if amount < 1 {
+		return Balance{}, ErrBadAmount
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	if _, exist := l.scales[asset]; !exist {
+		return Balance{}, ErrAssetNotFound
+	}
+	b := l.balance(account, asset)
+	if b.Available < amount {
+		return *b, ErrInsufficientFunds
+	}
+	if err := l.writeJournal(journalEntry{Type: journalWithdraw, Account: account, Asset: asset, Amount: amount}); err != nil {
+		return *b, err
+	}
+	b.Available -= amount
+	return *b, nil
+}
+
+// Balance returns the balance of the asset of the account
+func (l *Ledger) Balance(account, asset string) Balance {
+	l.Lock()
+	defer l.Unlock()
+	if b, exist := l.balances[account][asset]; exist {
+		return *b
+	}
+	return Balance{Asset: asset}
+}
+
+// Balances returns the balances of the account sorted by the assets
+func (l *Ledger) Balances(account string) []Balance {
+	l.Lock()
+	defer l.Unlock()
+	balances := make([]Balance, 0, len(l.balances[account]))
+	for _, b := range l.balances[account] {
+		balances = append(balances, *b)
+	}
+	sort.Slice(balances, func(i, j int) bool { return balances[i].Asset < balances[j].Asset })
+	return balances
+}
+
+// balance returns the balance of the asset of the account, it is created if it does not exist.
+// It should be called with lock.
+func (l *Ledger) balance(account, asset string) *Balance {
+	assets, exist := l.balances[account]
+	if !exist {
+		assets = make(map[string]*Balance)
+		l.balances[account] = assets
+	}
+	b, exist := assets[asset]
+	if !exist {
+		b = &Balance{Asset: asset}
+		assets[asset] = b
+	}
+	return b
+}
+
+// reserve sets the funds which are held by the order to the amount, the difference is moved from or to the
+// available balance. It returns ErrInsufficientFunds if the available balance is not enough.
+func (l *Ledger) reserve(id uuid.UUID, account, asset string, amount int) error {
+	l.Lock()
+	defer l.Unlock()
+	current := 0
+	if h, exist := l.holds[id]; exist {
+		current = h.amount
+	}
+	if amount-current > l.balance(account, asset).Available {
+		return ErrInsufficientFunds
+	}
+	e := journalEntry{Type: journalHold, ID: id.String(), Account: account, Asset: asset, Amount: amount}
+	if err := l.writeJournal(e); err != nil {
+		return err
+	}
+	l.hold(id, account, asset, amount)
+	return nil
+}
+
+// hold sets the funds which are held by the order to the amount without checking the available balance,
+// it should be called with lock
+func (l *Ledger) hold(id uuid.UUID, account, asset string, amount int) {
+	h, exist := l.holds[id]
+	if !exist {
+		h = &hold{account: account, asset: asset}
+	}
+	b := l.balance(h.account, h.asset)
+	b.Available -= amount - h.amount
+	b.Held += amount - h.amount
+	h.amount = amount
+	if amount > 0 {
+		l.holds[id] = h
+	} else {
+		delete(l.holds, id)
+	}
+}
+
+// held returns the funds which are held by the order
+func (l *Ledger) held(id uuid.UUID) int {
+	l.Lock()
+	defer l.Unlock()
+	if h, exist := l.holds[id]; exist {
+		return h.amount
+	}
+	return 0
+}
+
+// trim releases the funds which are held by the order over the amount, the order without the held funds is ignored
+func (l *Ledger) trim(id uuid.UUID, amount int) {
+	l.Lock()
+	defer l.Unlock()
+	h, exist := l.holds[id]
+	if !exist || h.amount <= amount {
+		return
+	}
+	// the funds are released even if it fails to journal them, the trade is already applied
+	e := journalEntry{Type: journalHold, ID: id.String(), Account: h.account, Asset: h.asset, Amount: amount}
+	if err := l.writeJournal(e); err != nil {
+		log.Println("ledger: failed to journal the released funds:", err)
+	}
+	l.hold(id, h.account, h.asset, amount)
+}
+
+// transfer settles a fill: the amount of the asset is paid from the funds which are held by the order and the
+// received amount is added to the available balance. The fills are capped by the held funds (see payable), so the
+// available balance is never overdrawn.
+func (l *Ledger) transfer(id uuid.UUID, account, paid string, amount int, received string, receivedAmount int) {
+	l.Lock()
+	defer l.Unlock()
+	// the fill is settled even if it fails to journal it, the trade is already applied
+	e := journalEntry{
+		Type:           journalSettle,
+		ID:             id.String(),
+		Account:        account,
+		Asset:          paid,
+		Amount:         amount,
+		Received:       received,
+		ReceivedAmount: receivedAmount,
+	}
+	if err := l.writeJournal(e); err != nil {
+		log.Println("ledger: failed to journal the settlement:", err)
+	}
+	l.settle(id, account, paid, amount, received, receivedAmount)
+}
+
+// settle applies the settlement of transfer, it should be called with lock
+func (l *Ledger) settle(id uuid.UUID, account, paid string, amount int, received string, receivedAmount int) {
+	if h, exist := l.holds[id]; exist {
+		if h.amount < amount {
+			amount = h.amount
+		}
+		h.amount -= amount
+		if h.amount == 0 {
+			delete(l.holds, id)
+		}
+		l.balance(account, paid).Held -= amount
+	}
+	l.balance(account, received).Available += receivedAmount
+}
+
+// ReleaseStale releases the funds which are held by the orders that are not resting in the orderbooks, they are
+// left by a crash between writing the journal of the ledger and the journals of the orderbooks. It should be called
+// after the orderbooks are rebuilt and before they accept any order, it returns the number of the released holds.
+func (l *Ledger) ReleaseStale(books ...*OrderBook) int {
+	resting := make(map[uuid.UUID]bool)
+	for _, ob := range books {
+		ob.RLock()
+		for _, q := range []*bookSide{ob.bids, ob.asks} {
+			for _, o := range q.orders() {
+				resting[o.ID] = true
+			}
+		}
+		ob.RUnlock()
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	released := 0
+	for id, h := range l.holds {
+		if resting[id] {
+			continue
+		}
+		if err := l.writeJournal(journalEntry{Type: journalHold, ID: id.String(), Account: h.account, Asset: h.asset}); err != nil {
+			log.Println("ledger: failed to journal the released funds:", err)
+		}
+		l.hold(id, h.account, h.asset, 0)
+		released++
+	}
+	return released
+}
+
+// writeJournal writes the change to the journal if the ledger has one, it should be called with lock
+func (l *Ledger) writeJournal(e journalEntry) error {
+	if l.journal == nil {
+		return nil
+	}
+	e.Seq = l.journalSeq + 1
+	if err := l.journal.write(e); err != nil {
+		return err
+	}
+	l.journalSeq = e.Seq
+	return nil
+}
+
+// Replay rebuilds the ledger by applying the changes in its journal, it should be called before the orderbooks
+// accept any order and after the snapshot is restored, the changes in the snapshot are skipped
+func (l *Ledger) Replay() error {
+	if l.journal == nil {
+		return nil
+	}
+	entries, err := l.journal.entries()
+	if err != nil {
+		return err
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	for i, e := range entries {
+		if e.Seq <= l.journalSeq {
+			continue
+		}
+		if err := l.apply(e); err != nil {
+			return fmt.Errorf("%w: entry %d: %v", ErrBadJournal, i+1, err)
+		}
+		l.journalSeq = e.Seq
+	}
+	return nil
+}
+
+// apply applies the change of the journal, it should be called with lock
+func (l *Ledger) apply(e journalEntry) error {
+	switch e.Type {
+	case journalDeposit:
+		l.balance(e.Account, e.Asset).Available += e.Amount
+		return nil
+	case journalWithdraw:
+		l.balance(e.Account, e.Asset).Available -= e.Amount
+		return nil
+	case journalHold, journalSettle:
+		id, err := uuid.Parse(e.ID)
+		if err != nil {
+			return err
+		}
+		if e.Type == journalHold {
+			l.hold(id, e.Account, e.Asset, e.Amount)
+		} else {
+			l.settle(id, e.Account, e.Asset, e.Amount, e.Received, e.ReceivedAmount)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown command %q", e.Type)
+}
+
+// Snapshot writes the snapshot of the balances and the held funds to w
+func (l *Ledger) Snapshot(w io.Writer) error {
+	l.Lock()
+	defer l.Unlock()
+	return l.snapshot(w)
+}
+
+// snapshot writes the snapshot of the ledger to w, it should be called with lock
+func (l *Ledger) snapshot(w io.Writer) error {
+	state := snapshotState{
+		JournalSeq: l.journalSeq,
+		Balances:   make(map[string][]Balance, len(l.balances)),
+		Holds:      make(map[string]snapshotHold, len(l.holds)),
+	}
+	for account, assets := range l.balances {
+		for _, b := range assets {
+			state.Balances[account] = append(state.Balances[account], *b)
+		}
+	}
+	for id, h := range l.holds {
+		state.Holds[id.String()] = snapshotHold{Account: h.account, Asset: h.asset, Amount: h.amount}
+	}
+	return writeSnapshot(w, &state)
+}
+
+// Restore replaces the balances and the held funds by the snapshot from r
+func (l *Ledger) Restore(r io.Reader) error {
+	state, err := readSnapshot(r)
+	if err != nil {
+		return err
+	}
+	holds := make(map[uuid.UUID]*hold, len(state.Holds))
+	for id, h := range state.Holds {
+		uid, err := uuid.Parse(id)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrBadSnapshot, err)
+		}
+		holds[uid] = &hold{account: h.Account, asset: h.Asset, amount: h.Amount}
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	l.balances = make(map[string]map[string]*Balance, len(state.Balances))
+	for account, balances := range state.Balances {
+		for _, b := range balances {
+			*l.balance(account, b.Asset) = b
+		}
+	}
+	l.holds = holds
+	l.journalSeq = state.JournalSeq
+	return nil
+}
+
+// SaveSnapshot writes the snapshot to the path of the snapshot of the ledger, and the journal is truncated because
+// all of its changes are included in the snapshot
+func (l *Ledger) SaveSnapshot() error {
+	if len(l.snapshotPath) == 0 {
+		return ErrSnapshotDisabled
+	}
+
+	// the changes are journaled with the lock, so the snapshot and the journal are consistent
+	l.Lock()
+	defer l.Unlock()
+	if err := saveSnapshotFile(l.snapshotPath, l.snapshot); err != nil {
+		return err
+	}
+	if l.journal != nil {
+		return l.journal.truncate()
+	}
+	return nil
+}
+
+// LoadSnapshot restores the ledger by the snapshot at the path of the snapshot of the ledger, it does nothing if
+// the snapshot does not exist. It should be called before Replay.
+func (l *Ledger) LoadSnapshot() error {
+	if len(l.snapshotPath) == 0 {
+		return ErrSnapshotDisabled
+	}
+	return loadSnapshotFile(l.snapshotPath, l.Restore)
+}
+
+// AutoSnapshot is the routine for taking the snapshots of the ledger periodically, it returns immediately if the
+// interval of the snapshot is 0
+func (l *Ledger) AutoSnapshot(ctx context.Context) {
+	if l.snapshotInterval == 0 {
+		return
+	}
+	log.Printf("take snapshot of the ledger to %s every %s\n", l.snapshotPath, l.snapshotInterval)
+	ticker := time.NewTicker(l.snapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := l.SaveSnapshot(); err != nil {
+				log.Println("ledger: failed to take the snapshot:", err)
+			}
+		case <-ctx.Done():
+			log.Println("ledger: auto snapshot is leaving...")
+			return
+		}
+	}
+}
+
+// funds returns the asset and the amount which are required by the order with the price and qty. The buy market
+// order requires the cost of the fills which it would get from the resting orders that trade matches.
+func (ob *OrderBook) funds(o *Order, price, qty int) (string, int) {
+	if o.Side == Sell {
+		return ob.instrument.Base, qty
+	}
+	if o.PriceMode != Market {
+		return ob.instrument.Quote, price * qty
+	}
+
+	cost := 0
+	ob.matchable(o, qty, func(price, fillQty int) {
+		cost += price * fillQty
+	})
+	return ob.instrument.Quote, cost
+}
+
+// payable returns the qty of the fill at the price which is covered by the funds held by the order, the order of the
+// orderbook without the ledger pays for any qty. It should be called with lock.
+func (ob *OrderBook) payable(o *Order, price, qty int) int {
+	if ob.ledger == nil || ob.replaying || len(o.Account) == 0 {
+		return qty
+	}
+	held := ob.ledger.held(o.ID)
+	if o.Side == Buy {
+		held /= price
+	}
+	if held < qty {
+		return held
+	}
+	return qty
+}
+
+// reserve holds the funds of the order before it trades, it should be called with lock
+func (ob *OrderBook) reserve(o *Order) error {
+	if ob.ledger == nil || ob.replaying {
+		return nil
+	}
+	if len(o.Account) == 0 {
+		return ErrAccountRequired
+	}
+	asset, amount := ob.funds(o, o.Price, o.Qty+o.HiddenQty)
+	return ob.ledger.reserve(o.ID, o.Account, asset, amount)
+}
+
+// reserveAmend holds the funds of the resting order with the new price and qty before it is amended, the returned
+// function restores the held funds if the amendment fails. It should be called with lock.
+func (ob *OrderBook) reserveAmend(o *Order, price, qty int) (func(), error) {
+	if ob.ledger == nil || ob.replaying || o.untriggered() || len(o.Account) == 0 {
+		return func() {}, nil
+	}
+	asset, amount := ob.funds(o, price, qty)
+	prev := ob.ledger.held(o.ID)
+	if err := ob.ledger.reserve(o.ID, o.Account, asset, amount); err != nil {
+		return nil, err
+	}
+	return func() { ob.ledger.reserve(o.ID, o.Account, asset, prev) }, nil
+}
+
+// release releases the funds of the order over the ones which are required by the rest of the order, all of them
+// are released if the order is done or canceled. It should be called with lock.
+func (ob *OrderBook) release(o *Order, done bool) {
+	if ob.ledger == nil || ob.replaying {
+		return
+	}
+	if done || o.PriceMode == Market {
+		ob.ledger.trim(o.ID, 0)
+		return
+	}
+	_, amount := ob.funds(o, o.Price, o.Qty+o.HiddenQty)
+	ob.ledger.trim(o.ID, amount)
+}
+
+// settle settles the balances of the buyer and seller by the fill, it should be called with lock
+func (ob *OrderBook) settle(fill Fill, maker, taker *Order) {
+	if ob.ledger == nil || ob.replaying {
+		return
+	}
+	base, quote := ob.instrument.Base, ob.instrument.Quote
+	for _, o := range []*Order{maker, taker} {
+		if len(o.Account) == 0 {
+			continue
+		}
+		if o.Side == Buy {
+			ob.ledger.transfer(o.ID, o.Account, quote, fill.Price*fill.Qty, base, fill.Qty)
+		} else {
+			ob.ledger.transfer(o.ID, o.Account, base, fill.Qty, quote, fill.Price*fill.Qty)
+		}
+		// the buy limit order which trades at a better price releases the difference
+		if o.Qty+o.HiddenQty == 0 {
+			ob.release(o, true)
+		} else if o.PriceMode != Market {
+			ob.release(o, false)
+		}
+	}
+}
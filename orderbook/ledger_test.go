@@ -0,0 +1,237 @@
+package orderbook
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLedger(t *testing.T) {
+
+	t.Log("start testing the balances of the ledger...")
+
+	l, err := NewLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(WithLedger(l)); !errors.Is(err, ErrBadInstrument) {
+		t.Fatal("the instrument without assets should be rejected", err)
+	}
+	inst := DefaultInstrument
+	inst.Base, inst.Quote = "BTC", "USD"
+	ob, err := New(WithLedger(l), WithInstrumentSpec(inst))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	balance := func(account, asset string, available, held int) {
+		t.Helper()
+		if b := l.Balance(account, asset); b.Available != available || b.Held != held {
+			t.Fatalf("the %s of %s should be %d/%d, but got %s", asset, account, available, held, b)
+		}
+	}
+
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 5); err != ErrAccountRequired {
+		t.Fatal("wrong error type", err)
+	}
+	if _, err := l.Deposit("alice", "ETH", 1); err != ErrAssetNotFound {
+		t.Fatal("wrong error type", err)
+	}
+	l.Deposit("alice", "USD", 1000)
+	l.Deposit("bob", "BTC", 10)
+	if _, err := ob.ProcessLimitOrder(Buy, 101, 20, WithAccount("alice")); err != ErrInsufficientFunds {
+		t.Fatal("wrong error type", err)
+	}
+
+	// the resting orders hold the funds
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 5, WithAccount("alice")); err != nil {
+		t.Fatal(err)
+	}
+	balance("alice", "USD", 500, 500)
+
+	// the fills are settled at the price of the maker
+	if _, err := ob.ProcessLimitOrder(Sell, 99, 8, WithAccount("bob")); err != nil {
+		t.Fatal(err)
+	}
+	balance("alice", "USD", 500, 0)
+	balance("alice", "BTC", 5, 0)
+	balance("bob", "BTC", 2, 3)
+	balance("bob", "USD", 500, 0)
+
+	// the taker releases the difference of the better price
+	if _, err := ob.ProcessLimitOrder(Buy, 105, 3, WithAccount("alice")); err != nil {
+		t.Fatal(err)
+	}
+	balance("alice", "USD", 203, 0)
+	balance("alice", "BTC", 8, 0)
+	balance("bob", "BTC", 2, 0)
+	balance("bob", "USD", 797, 0)
+
+	// the market order holds the cost of the sweep
+	if _, err := ob.ProcessLimitOrder(Sell, 100, 2, WithAccount("bob")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessMarketOrder(Buy, 5, WithAccount("alice")); err != nil {
+		t.Fatal(err)
+	}
+	balance("alice", "USD", 3, 0)
+	balance("alice", "BTC", 10, 0)
+
+	// the canceled and amended orders release the funds
+	id, err := ob.ProcessLimitOrder(Buy, 1, 3, WithAccount("alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.AmendOrder(id, 1, 4); err != ErrInsufficientFunds {
+		t.Fatal("wrong error type", err)
+	}
+	if err := ob.AmendOrder(id, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	balance("alice", "USD", 2, 1)
+	if err := ob.CancelOrder(id); err != nil {
+		t.Fatal(err)
+	}
+	balance("alice", "USD", 3, 0)
+
+	if _, err := l.Withdraw("", "USD", 1); err != ErrAccountRequired {
+		t.Fatal("wrong error type", err)
+	}
+	if balances := l.Balances(""); len(balances) != 0 {
+		t.Fatal("the empty account should not be created", balances)
+	}
+	if _, err := l.Withdraw("bob", "USD", 998); err != ErrInsufficientFunds {
+		t.Fatal("wrong error type", err)
+	}
+	if b, err := l.Withdraw("bob", "USD", 997); err != nil || b.Available != 0 {
+		t.Fatal("the USD of bob should be withdrawn", b, err)
+	}
+	if balances := l.Balances("alice"); len(balances) != 2 || balances[0].Asset != "BTC" || balances[1].Asset != "USD" {
+		t.Fatal("the balances should be sorted by the assets", balances)
+	}
+
+	// the market order holds the cost of the orders which it would trade with, the order of the same account which
+	// is canceled by the self-trade prevention is not counted
+	l.Deposit("carol", "BTC", 10)
+	l.Deposit("carol", "USD", 1050)
+	l.Deposit("dave", "BTC", 10)
+	ob.ProcessLimitOrder(Sell, 100, 10, WithAccount("carol"))
+	ob.ProcessLimitOrder(Sell, 110, 10, WithAccount("dave"))
+	if _, err := ob.ProcessMarketOrder(Buy, 10, WithAccount("carol"), WithSTP(STPCancelOldest)); err != ErrInsufficientFunds {
+		t.Fatal("wrong error type", err)
+	}
+	balance("carol", "USD", 1050, 0)
+	l.Deposit("carol", "USD", 50)
+	if _, err := ob.ProcessMarketOrder(Buy, 10, WithAccount("carol"), WithSTP(STPCancelOldest)); err != nil {
+		t.Fatal(err)
+	}
+	balance("carol", "USD", 0, 0)
+	balance("carol", "BTC", 20, 0)
+	balance("dave", "USD", 1100, 0)
+	t.Log("... Passed")
+}
+
+func TestLedgerJournal(t *testing.T) {
+
+	t.Log("start testing the journal and the snapshot of the ledger...")
+
+	dir := t.TempDir()
+	inst := DefaultInstrument
+	inst.Base, inst.Quote = "BTC", "USD"
+	open := func(journaled bool) (*Ledger, *OrderBook, func()) {
+		t.Helper()
+		lj, err := OpenJournal(filepath.Join(dir, "ledger.journal"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		l, err := NewLedger(WithLedgerJournal(lj), WithLedgerSnapshot(filepath.Join(dir, "ledger.snapshot"), 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts := []Option{WithLedger(l), WithInstrumentSpec(inst)}
+		var j *Journal
+		if journaled {
+			if j, err = OpenJournal(filepath.Join(dir, "BTCUSD.journal")); err != nil {
+				t.Fatal(err)
+			}
+			opts = append(opts, WithJournal(j))
+		}
+		ob, err := New(opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := l.LoadSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+		if err := l.Replay(); err != nil {
+			t.Fatal(err)
+		}
+		if err := ob.Replay(); err != nil {
+			t.Fatal(err)
+		}
+		return l, ob, func() {
+			ob.Close()
+			lj.Close()
+			if j != nil {
+				j.Close()
+			}
+		}
+	}
+	sameBalances := func(want, got *Ledger, accounts ...string) {
+		t.Helper()
+		for _, account := range accounts {
+			if w, g := want.Balances(account), got.Balances(account); !reflect.DeepEqual(w, g) {
+				t.Fatalf("the balances of %s should be %v, but got %v", account, w, g)
+			}
+		}
+	}
+
+	l, ob, shutdown := open(true)
+	l.Deposit("alice", "USD", 1000)
+	l.Deposit("bob", "BTC", 10)
+	ob.ProcessLimitOrder(Sell, 100, 5, WithAccount("bob"))
+	ob.ProcessLimitOrder(Buy, 100, 3, WithAccount("alice"))
+	ob.ProcessLimitOrder(Buy, 90, 2, WithAccount("alice"))
+	if _, err := l.Withdraw("bob", "USD", 100); err != nil {
+		t.Fatal(err)
+	}
+	shutdown()
+
+	// the balances and the held funds are rebuilt by the journal
+	replayed, ob, shutdown := open(true)
+	sameBalances(l, replayed, "alice", "bob")
+	if n := replayed.ReleaseStale(ob); n != 0 {
+		t.Fatal("the funds of the resting orders should be kept", n)
+	}
+
+	// the changes after the snapshot are replayed
+	if err := replayed.SaveSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(filepath.Join(dir, "ledger.journal")); err != nil || info.Size() != 0 {
+		t.Fatal("the journal should be truncated", err)
+	}
+	replayed.Deposit("carol", "USD", 50)
+	shutdown()
+	restored, _, shutdown := open(true)
+	sameBalances(replayed, restored, "alice", "bob", "carol")
+	shutdown()
+
+	// the funds of the orders which are not in the orderbook are released
+	restored, ob, shutdown = open(false)
+	defer shutdown()
+	if n := restored.ReleaseStale(ob); n != 2 {
+		t.Fatal("the funds of 2 orders should be released, but got", n)
+	}
+	for _, account := range []string{"alice", "bob"} {
+		for _, b := range restored.Balances(account) {
+			if b.Held != 0 {
+				t.Fatal("the funds should be released", account, b)
+			}
+		}
+	}
+	t.Log("... Passed")
+}
@@ -0,0 +1,215 @@
+package orderbook
+
+import (
+	"container/list"
+
+	"github.com/google/uuid"
+)
+
+// Level is the aggregated orders of a price level
+type Level struct {
+	Price  int `json:"price"`
+	Qty    int `json:"quantity"`
+	Orders int `json:"orders"`
+}
+
+// Depth is the aggregated price levels of each side by priority
+type Depth struct {
+	Bids []Level `json:"bids"`
+	Asks []Level `json:"asks"`
+}
+
+// priceLevel is the FIFO queue of the orders with the same price
+type priceLevel struct {
+	price int
+	// qty is the total qty of the orders in the level
+	qty    int
+	orders *list.List
+}
+
+// newPriceLevel returns an empty price level
+func newPriceLevel(price int) *priceLevel {
+	return &priceLevel{price: price, orders: list.New()}
+}
+
+// push pushes the order into the level by its time, the new order is always put at the back
+func (l *priceLevel) push(o *Order) *list.Element {
+	l.qty += o.Qty
+	e := l.orders.Back()
+	for e != nil && e.Value.(*Order).Time.After(o.Time) {
+		e = e.Prev()
+	}
+	if e == nil {
+		return l.orders.PushFront(o)
+	}
+	return l.orders.InsertAfter(o, e)
+}
+
+// remove removes the element of the order from the level
+func (l *priceLevel) remove(e *list.Element) {
+	l.qty -= e.Value.(*Order).Qty
+	l.orders.Remove(e)
+}
+
+// accountOrders is the number of the orders of each account
+type accountOrders map[string]int
+
+// add adds the delta to the number of the orders of the account of the order
+func (a accountOrders) add(o *Order, delta int) {
+	if len(o.Account) == 0 {
+		return
+	}
+	if n := a[o.Account] + delta; n > 0 {
+		a[o.Account] = n
+	} else {
+		delete(a, o.Account)
+	}
+}
+
+// bookSide is the orders of a side which are grouped by the price levels,
+// the price levels are sorted by the skiplist and the orders are indexed by id
+type bookSide struct {
+	side   Side
+	levels *skiplist
+	index  map[uuid.UUID]*list.Element
+	// accounts is the number of the orders of each account
+	accounts accountOrders
+	// touched saves the prices of the changed levels since the last flush
+	touched map[int]struct{}
+	// touchedPrices is the prices of the touched map by the sequence of changes
+	touchedPrices []int
+}
+
+// newBookSide returns an empty side, the bids are sorted by the price descending
+// and the asks are sorted by the price ascending
+func newBookSide(side Side) *bookSide {
+	less := func(a, b int) bool { return a < b }
+	if side == Buy {
+		less = func(a, b int) bool { return a > b }
+	}
+	return &bookSide{
+		side:     side,
+		levels:   newSkiplist(less),
+		index:    make(map[uuid.UUID]*list.Element),
+		accounts: make(accountOrders),
+		touched:  make(map[int]struct{}),
+	}
+}
+
+// touch marks the price level of the order is changed
+func (b *bookSide) touch(o *Order) {
+	if _, exist := b.touched[o.Price]; !exist {
+		b.touched[o.Price] = struct{}{}
+		b.touchedPrices = append(b.touchedPrices, o.Price)
+	}
+}
+
+// flush returns the prices of the changed levels since the last flush and resets them
+func (b *bookSide) flush() []int {
+	prices := b.touchedPrices
+	b.touchedPrices = nil
+	for _, price := range prices {
+		delete(b.touched, price)
+	}
+	return prices
+}
+
+// Len returns the number of the orders
+func (b *bookSide) Len() int { return len(b.index) }
+
+// level returns the price level of the order, nil if the level does not exist
+func (b *bookSide) level(o *Order) *priceLevel {
+	return b.levels.get(o.Price)
+}
+
+// push pushes the order into its price level
+func (b *bookSide) push(o *Order) {
+	l := b.level(o)
+	if l == nil {
+		l = newPriceLevel(o.Price)
+		b.levels.insert(l)
+	}
+	b.index[o.ID] = l.push(o)
+	b.accounts.add(o, 1)
+	b.touch(o)
+}
+
+// get returns the order by id, nil if the order does not exist
+func (b *bookSide) get(id uuid.UUID) *Order {
+	e, exist := b.index[id]
+	if !exist {
+		return nil
+	}
+	return e.Value.(*Order)
+}
+
+// remove removes the order from its price level, the empty level is removed as well
+func (b *bookSide) remove(o *Order) {
+	e, exist := b.index[o.ID]
+	if !exist {
+		return
+	}
+	l := b.level(o)
+	l.remove(e)
+	delete(b.index, o.ID)
+	b.accounts.add(o, -1)
+	b.touch(o)
+	if l.orders.Len() == 0 {
+		b.levels.remove(l.price)
+	}
+}
+
+// best returns the order with the highest priority, nil if the side is empty
+func (b *bookSide) best() *Order {
+	if n := b.levels.first(); n != nil {
+		return n.level.orders.Front().Value.(*Order)
+	}
+	return nil
+}
+
+// fill fills the order with the price & qty, the order is removed if it is completely filled
+func (b *bookSide) fill(o *Order, price, qty int) {
+	b.level(o).qty -= qty
+	b.touch(o)
+	o.fill(price, qty)
+	if o.Qty == 0 {
+		b.remove(o)
+	}
+}
+
+// resize changes the remaining qty of the order in place
+func (b *bookSide) resize(o *Order, qty int) {
+	b.level(o).qty += qty - o.Qty
+	b.touch(o)
+	o.Qty = qty
+}
+
+// walk calls fn for each price level by priority until fn returns false
+func (b *bookSide) walk(fn func(l *priceLevel) bool) {
+	for n := b.levels.first(); n != nil; n = n.next[0] {
+		if !fn(n.level) {
+			return
+		}
+	}
+}
+
+// orders returns the orders by priority
+func (b *bookSide) orders() []*Order {
+	orders := make([]*Order, 0, b.Len())
+	b.walk(func(l *priceLevel) bool {
+		for e := l.orders.Front(); e != nil; e = e.Next() {
+			orders = append(orders, e.Value.(*Order))
+		}
+		return true
+	})
+	return orders
+}
+
+// depth returns the top n price levels, all the price levels are returned if n < 1
+func (b *bookSide) depth(n int) []Level {
+	levels := make([]Level, 0)
+	for node := b.levels.first(); node != nil && (n < 1 || len(levels) < n); node = node.next[0] {
+		levels = append(levels, Level{Price: node.price, Qty: node.level.qty, Orders: node.level.orders.Len()})
+	}
+	return levels
+}
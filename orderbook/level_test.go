@@ -0,0 +1,222 @@
+package orderbook
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestSkiplist(t *testing.T) {
+	testcases := []struct {
+		side   Side
+		prices []int
+		want   []int
+	}{
+		{
+			side:   Buy,
+			prices: []int{10, 30, 20, 50, 40},
+			want:   []int{50, 40, 30, 20, 10},
+		},
+		{
+			side:   Sell,
+			prices: []int{10, 30, 20, 50, 40},
+			want:   []int{10, 20, 30, 40, 50},
+		},
+	}
+
+	for _, tt := range testcases {
+		levels := newBookSide(tt.side).levels
+		for _, price := range tt.prices {
+			levels.insert(newPriceLevel(price))
+		}
+
+		got := make([]int, 0, levels.Len())
+		for n := levels.first(); n != nil; n = n.next[0] {
+			got = append(got, n.price)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Fatalf("the sequence of side: %s should be %v, but got %v", tt.side, tt.want, got)
+		}
+
+		if levels.get(30) == nil || levels.get(35) != nil {
+			t.Fatal("wrong level of the price")
+		}
+
+		levels.remove(30)
+		levels.remove(35) // not exist
+		if levels.get(30) != nil || levels.Len() != len(tt.prices)-1 {
+			t.Fatal("the level of the price should be removed")
+		}
+	}
+}
+
+func TestBookSide(t *testing.T) {
+	q := newBookSide(Sell)
+
+	orders := make([]*Order, 0)
+	for _, price := range []int{101, 100, 101, 100} {
+		order, err := NewOrder(Sell, price, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		order.PriceMode = Limit
+		q.push(order)
+		orders = append(orders, order)
+	}
+
+	// price priority then time priority
+	if best := q.best(); best != orders[1] {
+		t.Fatalf("the best order should be %s, but got %s", orders[1], best)
+	}
+	if l := q.levels.get(100); l.qty != 20 || l.orders.Len() != 2 {
+		t.Fatal("wrong qty of the price level", l.qty)
+	}
+
+	// fill the best order partially, it keeps its priority
+	q.fill(orders[1], 100, 4)
+	if best := q.best(); best != orders[1] || q.levels.get(100).qty != 16 {
+		t.Fatalf("the best order should be %s, but got %s", orders[1], best)
+	}
+
+	// the empty level is removed
+	q.remove(orders[1])
+	q.fill(orders[3], 100, 10)
+	if q.levels.get(100) != nil || q.Len() != 2 {
+		t.Fatal("the empty level should be removed")
+	}
+	if q.get(orders[3].ID) != nil || q.get(orders[0].ID) != orders[0] {
+		t.Fatal("wrong index of the orders")
+	}
+	if best := q.best(); best != orders[0] {
+		t.Fatalf("the best order should be %s, but got %s", orders[0], best)
+	}
+}
+
+// newBenchOrders returns the limit orders with random prices around 1000
+func newBenchOrders(b *testing.B, side Side, n int) []*Order {
+	r := rand.New(rand.NewSource(1))
+	orders := make([]*Order, 0, n)
+	for i := 0; i < n; i++ {
+		order, err := NewOrder(side, 900+r.Intn(200), 1+r.Intn(100))
+		if err != nil {
+			b.Fatal(err)
+		}
+		order.PriceMode = Limit
+		orders = append(orders, order)
+	}
+	return orders
+}
+
+var benchSizes = []int{1000, 10000, 50000}
+
+func BenchmarkOrdersHeapPush(b *testing.B) {
+	for _, n := range benchSizes {
+		orders := newBenchOrders(b, Buy, n)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				q := make(Orders, 0, n)
+				for _, order := range orders {
+					heap.Push(&q, order)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBookSidePush(b *testing.B) {
+	for _, n := range benchSizes {
+		orders := newBenchOrders(b, Buy, n)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				q := newBookSide(Buy)
+				for _, order := range orders {
+					q.push(order)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOrdersHeapLookup(b *testing.B) {
+	for _, n := range benchSizes {
+		orders := newBenchOrders(b, Buy, n)
+		q := make(Orders, 0, n)
+		for _, order := range orders {
+			heap.Push(&q, order)
+		}
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				id := orders[i%n].ID
+				for _, order := range q {
+					if order.ID == id {
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBookSideLookup(b *testing.B) {
+	for _, n := range benchSizes {
+		orders := newBenchOrders(b, Buy, n)
+		q := newBookSide(Buy)
+		for _, order := range orders {
+			q.push(order)
+		}
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				q.get(orders[i%n].ID)
+			}
+		})
+	}
+}
+
+func BenchmarkOrdersHeapCancel(b *testing.B) {
+	for _, n := range benchSizes {
+		orders := newBenchOrders(b, Buy, n)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				q := make(Orders, 0, n)
+				for _, order := range orders {
+					heap.Push(&q, order)
+				}
+				b.StartTimer()
+				// cancel 100 orders by id
+				for j := 0; j < 100; j++ {
+					id := orders[j*(n/100)].ID
+					for _, order := range q {
+						if order.ID == id {
+							heap.Remove(&q, order.idx)
+							break
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBookSideCancel(b *testing.B) {
+	for _, n := range benchSizes {
+		orders := newBenchOrders(b, Buy, n)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				q := newBookSide(Buy)
+				for _, order := range orders {
+					q.push(order)
+				}
+				b.StartTimer()
+				// cancel 100 orders by id
+				for j := 0; j < 100; j++ {
+					if order := q.get(orders[j*(n/100)].ID); order != nil {
+						q.remove(order)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package orderbook
+
+// WithMarketProtection is an option for the default max. slippage of the market orders in basis points, the market
+// order without its own protection never trades worse than the best opposite price at its arrival by the slippage.
+// The market orders are not protected if it is 0.
+func WithMarketProtection(bps int) Option {
+	return func(ob *OrderBook) error {
+		if bps < 0 {
+			return ErrBadSlippage
+		}
+		ob.slippage = bps
+		return nil
+	}
+}
+
+// WithProtectionPrice is an option for the price band of the market order, the order sweeps the price levels up to
+// the price and the rest of it is canceled
+func WithProtectionPrice(price int) OrderOption {
+	return func(o *Order) error {
+		if price < 1 {
+			return ErrBadProtectionPrice
+		}
+		o.ProtectionPrice = price
+		return nil
+	}
+}
+
+// WithMaxSlippage is an option for the max. slippage of the market order in basis points, the protection price of
+// the order is the best opposite price at its arrival (or activation) moved by the slippage
+func WithMaxSlippage(bps int) OrderOption {
+	return func(o *Order) error {
+		if bps < 1 {
+			return ErrBadSlippage
+		}
+		o.Slippage = bps
+		return nil
+	}
+}
+
+// protect sets the protection price of the market order by its slippage or the default slippage of the orderbook,
+// the buy price is rounded down and the sell price is rounded up to the tick size. It should be called with lock.
+func (ob *OrderBook) protect(o *Order) {
+	bps := o.Slippage
+	if bps == 0 {
+		bps = ob.slippage
+	}
+	if o.ProtectionPrice > 0 || bps == 0 {
+		return
+	}
+	best := ob.opposite(o.Side).best()
+	if best == nil {
+		return
+	}
+
+	tick := ob.instrument.TickSize
+	if o.Side == Buy {
+		o.ProtectionPrice = best.Price * (10000 + bps) / 10000 / tick * tick
+		return
+	}
+	price := (best.Price*(10000-bps) + 9999) / 10000
+	price = (price + tick - 1) / tick * tick
+	if price < tick {
+		price = tick
+	}
+	o.ProtectionPrice = price
+}
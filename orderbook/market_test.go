@@ -0,0 +1,105 @@
+package orderbook
+
+import (
+	"testing"
+)
+
+func TestMarketProtection(t *testing.T) {
+
+	t.Log("start testing the protection of the market order...")
+
+	if _, err := New(WithMarketProtection(-1)); err != ErrBadSlippage {
+		t.Fatal("wrong error type", err)
+	}
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+	ch := ob.SubscribeExecutions(ExecutionFilter{}, 64)
+
+	if _, err := ob.ProcessMarketOrder(Buy, 10, WithProtectionPrice(0)); err != ErrBadProtectionPrice {
+		t.Fatal("wrong error type", err)
+	}
+	if _, err := ob.ProcessMarketOrder(Buy, 10, WithMaxSlippage(0)); err != ErrBadSlippage {
+		t.Fatal("wrong error type", err)
+	}
+	for _, price := range []int{100, 101, 103} {
+		if _, err := ob.ProcessLimitOrder(Sell, price, 5); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := ob.ProcessMarketOrder(Buy, 10, WithProtectionPrice(99)); err != ErrNoLiquidity {
+		t.Fatal("the market order should not trade beyond the protection price", err)
+	}
+
+	// the price band stops the sweep at 101
+	id, err := ob.ProcessMarketOrder(Buy, 20, WithProtectionPrice(101))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order Order
+	if status, _ := ob.GetOrder(id, &order); status != StatusCanceled || order.FilledQty != 10 || order.AvgPrice != 100.5 {
+		t.Fatal("the market order should fill 10 and cancel the rest", status, order)
+	}
+
+	// 2% of 103 is up to 105, so the slippage does not stop the order
+	id, err = ob.ProcessMarketOrder(Buy, 5, WithMaxSlippage(200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := ob.GetOrder(id, &order); status != StatusCompleted || order.ProtectionPrice != 105 {
+		t.Fatal("the market order should be filled within the slippage", status, order)
+	}
+
+	ob.UnsubscribeExecutions(ch)
+	canceled := 0
+	for r := range ch {
+		if r.Type == ExecCanceled && r.Reason == ErrMarketNotFilled.Error() {
+			canceled++
+		}
+	}
+	if canceled != 1 {
+		t.Fatal("the rest of the market order should be reported as canceled, but got", canceled)
+	}
+	t.Log("... Passed")
+}
+
+func TestDefaultMarketProtection(t *testing.T) {
+
+	t.Log("start testing the default protection of the market orders...")
+
+	ob, err := New(WithMarketProtection(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	for _, price := range []int{100, 99, 98} {
+		if _, err := ob.ProcessLimitOrder(Buy, price, 5); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 1% of 100 is down to 99
+	id, err := ob.ProcessMarketOrder(Sell, 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order Order
+	if status, _ := ob.GetOrder(id, &order); status != StatusCanceled || order.ProtectionPrice != 99 || order.Qty != 5 {
+		t.Fatal("the market order should be stopped at 99", status, order)
+	}
+	if bids := ob.GetBids(); len(bids) != 1 || bids[0].Price != 98 {
+		t.Fatal("the bid at 98 should rest", bids)
+	}
+
+	// the own protection price overrides the default one
+	if _, err := ob.ProcessMarketOrder(Sell, 5, WithProtectionPrice(90)); err != nil {
+		t.Fatal(err)
+	}
+	if len(ob.GetBids()) != 0 {
+		t.Fatal("the bid at 98 should be traded")
+	}
+	t.Log("... Passed")
+}
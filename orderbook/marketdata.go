@@ -0,0 +1,144 @@
+package orderbook
+
+import (
+	"fmt"
+	"time"
+)
+
+// MarketDataType is the type of the market data
+type MarketDataType int
+
+const (
+	// MarketDataSnapshot is the depth of the orderbook when the market data is subscribed
+	MarketDataSnapshot MarketDataType = iota
+	// MarketDataTop is the change of the best bid or the best ask
+	MarketDataTop
+	// MarketDataLevel is the incremental update of a price level, the qty of the level is 0 if it is removed
+	MarketDataLevel
+	// MarketDataTrade is the trade print
+	MarketDataTrade
+)
+
+func (m MarketDataType) String() string {
+	return [...]string{
+		"snapshot",
+		"top",
+		"level",
+		"trade",
+	}[m]
+}
+
+// TopOfBook is the best price level of each side, the level is zero if the side is empty
+type TopOfBook struct {
+	Bid Level `json:"bid"`
+	Ask Level `json:"ask"`
+}
+
+// MarketData is the event of the market data, the field is set by the type of the market data
+type MarketData struct {
+	// Seq is the sequence number of the market data, the events after the snapshot start from Seq+1 of the snapshot
+	Seq  uint64         `json:"seq"`
+	Type MarketDataType `json:"type"`
+	Time time.Time      `json:"time"`
+
+	Snapshot Depth     `json:"snapshot"`
+	Top      TopOfBook `json:"top"`
+	Side     Side      `json:"side"`
+	Level    Level     `json:"level"`
+	Trade    Fill      `json:"trade"`
+}
+
+func (m MarketData) String() string {
+	switch m.Type {
+	case MarketDataSnapshot:
+		return fmt.Sprintf("market data[%d]:[%s]-<[bids]: %v, [asks]: %v>", m.Seq, m.Type, m.Snapshot.Bids, m.Snapshot.Asks)
+	case MarketDataTop:
+		return fmt.Sprintf("market data[%d]:[%s]-<[bid]: %v, [ask]: %v>", m.Seq, m.Type, m.Top.Bid, m.Top.Ask)
+	case MarketDataLevel:
+		return fmt.Sprintf("market data[%d]:[%s]-<[%s]: %v>", m.Seq, m.Type, m.Side, m.Level)
+	default:
+		return fmt.Sprintf("market data[%d]:[%s]-<[price]: %d, [qty]: %d>", m.Seq, m.Type, m.Trade.Price, m.Trade.Qty)
+	}
+}
+
+// SubscribeMarketData returns the snapshot with the top price levels of the orderbook and the channel of the
+// market data after the snapshot, all the price levels are in the snapshot if levels < 1.
+// The channel is closed if the subscriber is too slow to receive the market data or it is unsubscribed.
+func (ob *OrderBook) SubscribeMarketData(levels, size int) (MarketData, <-chan MarketData) {
+	var (
+		snapshot MarketData
+		ch       <-chan MarketData
+	)
+	if err := ob.exec(func() error {
+		snapshot = MarketData{
+			Seq:      ob.seq,
+			Type:     MarketDataSnapshot,
+			Time:     ob.now(),
+			Snapshot: Depth{Bids: ob.bids.depth(levels), Asks: ob.asks.depth(levels)},
+		}
+		ch = ob.marketData.subscribe(size, nil)
+		return nil
+	}); err != nil {
+		return snapshot, closedChan[MarketData]()
+	}
+	return snapshot, ch
+}
+
+// UnsubscribeMarketData unsubscribes the market data and closes the channel
+func (ob *OrderBook) UnsubscribeMarketData(ch <-chan MarketData) {
+	ob.exec(func() error {
+		ob.marketData.unsubscribe(ch)
+		return nil
+	})
+}
+
+// top returns the top of the orderbook
+func (ob *OrderBook) top() TopOfBook {
+	var top TopOfBook
+	if bids := ob.bids.depth(1); len(bids) > 0 {
+		top.Bid = bids[0]
+	}
+	if asks := ob.asks.depth(1); len(asks) > 0 {
+		top.Ask = asks[0]
+	}
+	return top
+}
+
+// publish publishes the trades, the updates of the touched price levels and the change of the top of the orderbook
+// since the last publishing, it is called by the matching loop after each command
+func (ob *OrderBook) publish() {
+	trades := ob.trades
+	ob.trades = ob.trades[:0]
+	touched := [...][]int{ob.bids.flush(), ob.asks.flush()}
+	if ob.marketData.Len() == 0 {
+		ob.lastTop = ob.top()
+		return
+	}
+
+	now := ob.now()
+	send := func(md MarketData) {
+		ob.seq++
+		md.Seq = ob.seq
+		md.Time = now
+		ob.marketData.publish(md)
+	}
+
+	for _, trade := range trades {
+		send(MarketData{Type: MarketDataTrade, Trade: trade})
+	}
+
+	for i, q := range []*bookSide{ob.bids, ob.asks} {
+		for _, price := range touched[i] {
+			level := Level{Price: price}
+			if l := q.levels.get(price); l != nil {
+				level.Qty, level.Orders = l.qty, l.orders.Len()
+			}
+			send(MarketData{Type: MarketDataLevel, Side: q.side, Level: level})
+		}
+	}
+
+	if top := ob.top(); top != ob.lastTop {
+		ob.lastTop = top
+		send(MarketData{Type: MarketDataTop, Top: top})
+	}
+}
@@ -0,0 +1,115 @@
+package orderbook
+
+import (
+	"testing"
+)
+
+func TestMarketData(t *testing.T) {
+
+	t.Log("start testing market data of the orderbook...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, ch := ob.SubscribeMarketData(0, 100)
+	if snapshot.Type != MarketDataSnapshot || len(snapshot.Snapshot.Bids) != 1 || snapshot.Snapshot.Bids[0].Qty != 10 {
+		t.Fatal("wrong snapshot of the orderbook", snapshot)
+	}
+
+	// a new ask level and the top is changed
+	if _, err := ob.ProcessLimitOrder(Sell, 101, 5); err != nil {
+		t.Fatal(err)
+	}
+	// a trade and the bid level is updated
+	if _, err := ob.ProcessLimitOrder(Sell, 100, 4); err != nil {
+		t.Fatal(err)
+	}
+	// the bid level is removed and the top is changed
+	if _, err := ob.ProcessLimitOrder(Sell, 100, 6); err != nil {
+		t.Fatal(err)
+	}
+
+	wants := []struct {
+		typ   MarketDataType
+		side  Side
+		price int
+		qty   int
+	}{
+		{typ: MarketDataLevel, side: Sell, price: 101, qty: 5},
+		{typ: MarketDataTop},
+		{typ: MarketDataTrade, price: 100, qty: 4},
+		{typ: MarketDataLevel, side: Buy, price: 100, qty: 6},
+		{typ: MarketDataTop},
+		{typ: MarketDataTrade, price: 100, qty: 6},
+		{typ: MarketDataLevel, side: Buy, price: 100, qty: 0},
+		{typ: MarketDataTop},
+	}
+
+	for i, want := range wants {
+		md := <-ch
+		if md.Seq != snapshot.Seq+uint64(i)+1 {
+			t.Fatalf("the sequence number should be %d, but got %d", snapshot.Seq+uint64(i)+1, md.Seq)
+		}
+		if md.Type != want.typ {
+			t.Fatalf("the type of market data[%d] should be %s, but got %s", i, want.typ, md.Type)
+		}
+		switch md.Type {
+		case MarketDataLevel:
+			if md.Side != want.side || md.Level.Price != want.price || md.Level.Qty != want.qty {
+				t.Fatalf("wrong level update: %s", md)
+			}
+		case MarketDataTrade:
+			if md.Trade.Price != want.price || md.Trade.Qty != want.qty {
+				t.Fatalf("wrong trade print: %s", md)
+			}
+		}
+	}
+
+	// the last top has no bid
+	ob.UnsubscribeMarketData(ch)
+	if _, ok := <-ch; ok {
+		t.Fatal("the channel should be closed")
+	}
+	if top := ob.top(); top.Bid != (Level{}) || top.Ask.Price != 101 {
+		t.Fatal("wrong top of the orderbook", top)
+	}
+
+	t.Log("... Passed")
+}
+
+func TestSlowMarketDataSubscriber(t *testing.T) {
+
+	t.Log("start testing slow subscriber of market data...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ch := ob.SubscribeMarketData(0, 1)
+	for i := 0; i < 10; i++ {
+		if _, err := ob.ProcessLimitOrder(Buy, 100+i, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the channel is closed after the buffered market data
+	n := 0
+	for range ch {
+		n++
+	}
+	if n != 1 {
+		t.Fatalf("the number of the market data should be %d, but got %d", 1, n)
+	}
+
+	// unsubscribing the closed channel is fine
+	ob.UnsubscribeMarketData(ch)
+
+	t.Log("... Passed")
+}
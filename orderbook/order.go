@@ -8,15 +8,93 @@ import (
 	"github.com/google/uuid"
 )
 
+// WithTimeInForce is an option for the time in force of the order
+func WithTimeInForce(tif TimeInForce) OrderOption {
+	return func(o *Order) error {
+		if tif < GTC || tif > Day {
+			return ErrBadTimeInForce
+		}
+		o.TimeInForce = tif
+		return nil
+	}
+}
+
+// WithExpireTime is an option for the expire time of the GTD order
+func WithExpireTime(t time.Time) OrderOption {
+	return func(o *Order) error {
+		o.ExpireTime = t
+		return nil
+	}
+}
+
+// WithAccount is an option for the account which owns the order
+func WithAccount(account string) OrderOption {
+	return func(o *Order) error {
+		o.Account = account
+		return nil
+	}
+}
+
+// WithDisplayQty is an option for the display qty of the iceberg order, only the display qty rests in the book
+// and the rest is hidden. A new slice is displayed from the hidden qty when the displayed one is filled, and the
+// new slice loses its time priority.
+func WithDisplayQty(qty int) OrderOption {
+	return func(o *Order) error {
+		if qty < 1 {
+			return ErrBadDisplayQty
+		}
+		o.DisplayQty = qty
+		return nil
+	}
+}
+
+// WithPostOnly is an option for the post-only limit order which never takes liquidity, it is rejected or repriced
+// one tick away from the best opposite price by the mode if it would cross the spread
+func WithPostOnly(mode PostOnly) OrderOption {
+	return func(o *Order) error {
+		if mode < PostOnlyNone || mode > PostOnlySlide {
+			return ErrBadPostOnly
+		}
+		o.PostOnly = mode
+		return nil
+	}
+}
+
+// WithInstrument is an option for validating the price and qty of the order by the spec. of the instrument,
+// the price of the market order is not validated
+func WithInstrument(inst Instrument) OrderOption {
+	return func(o *Order) error {
+		if err := inst.Validate(); err != nil {
+			return err
+		}
+		return inst.checkOrder(o)
+	}
+}
+
 // NewOrder returns new order
-func NewOrder(side Side, price, qty int) (*Order, error) {
+func NewOrder(side Side, price, qty int, opts ...OrderOption) (*Order, error) {
+	return newOrder(uuid.New(), time.Now(), side, price, qty, opts...)
+}
+
+// newOrder returns new order with the id and the time
+func newOrder(id uuid.UUID, now time.Time, side Side, price, qty int, opts ...OrderOption) (*Order, error) {
 	if qty < 1 {
 		return nil, ErrBadOrderQty
 	}
 	if price < 1 {
 		return nil, ErrBadOrderPrice
 	}
-	o := &Order{ID: uuid.New(), Side: side, Price: price, Qty: qty, Time: time.Now(), PriceMode: Unknown}
+	o := &Order{ID: id, Side: side, Price: price, Qty: qty, OriginalQty: qty, Time: now, PriceMode: Unknown}
+
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.TimeInForce == GTD && !o.ExpireTime.After(o.Time) {
+		return nil, ErrBadExpireTime
+	}
 	return o, nil
 }
 
@@ -28,16 +106,104 @@ type Order struct {
 	Qty       int       `json:"quantity"`
 	Side      Side      `json:"side"`
 	Time      time.Time `json:"time"`
+	// Account is the owner of the order
+	Account string `json:"account"`
+
+	TimeInForce TimeInForce `json:"time_in_force"`
+	// ExpireTime is the expire time of the GTD and Day order
+	ExpireTime time.Time `json:"expire_time"`
+	// StopPrice is the trigger price of the stop order, 0 if the order is not a stop order.
+	// Triggered is true after the stop order is activated by the last trade price.
+	StopPrice int  `json:"stop_price"`
+	Triggered bool `json:"triggered"`
+	// DisplayQty is the size of the slices of the iceberg order, 0 if the order is not an iceberg order.
+	// HiddenQty is the remaining qty which is not displayed, the displayed qty of the resting order is Qty.
+	DisplayQty int `json:"display_quantity"`
+	HiddenQty  int `json:"hidden_quantity"`
+	// PostOnly is how the order is handled if it would take liquidity
+	PostOnly PostOnly `json:"post_only"`
+	// ReduceOnly is true if the order can only shrink the position of its account
+	ReduceOnly bool `json:"reduce_only"`
+	// STP is the mode of the self-trade prevention of the order, the default mode of the orderbook is applied if
+	// it is STPNone
+	STP STP `json:"stp"`
+	// ProtectionPrice is the worst price which the market order can trade at, 0 if it is not protected.
+	// Slippage is the max. slippage of the market order in basis points which sets the protection price.
+	ProtectionPrice int `json:"protection_price"`
+	Slippage        int `json:"slippage"`
+
+	// OriginalQty is the qty of the order when it is created or amended
+	OriginalQty int `json:"original_quantity"`
+	// FilledQty is the total qty of the fills of the order
+	FilledQty int `json:"filled_quantity"`
+	// AvgPrice is the average price of the fills of the order
+	AvgPrice float64 `json:"average_price"`
 
 	// idx is the index in the queue
 	idx int
+	// notional is the total price * qty of the fills
+	notional int
+	// selfTraded is true if the rest of the taker order is canceled by the self-trade prevention
+	selfTraded bool
+}
+
+// expired checks if the order is expired at the time or older than the live time of the orders
+func (o Order) expired(now time.Time, expiration time.Duration) bool {
+	if o.pastExpireTime(now) {
+		return true
+	}
+	return now.Sub(o.Time) > expiration
+}
+
+// pastExpireTime checks if the GTD or Day order is expired at the time
+func (o Order) pastExpireTime(now time.Time) bool {
+	return !o.ExpireTime.IsZero() && !now.Before(o.ExpireTime)
+}
+
+// RemainingQty returns the qty of the order is not filled
+func (o Order) RemainingQty() int {
+	return o.OriginalQty - o.FilledQty
+}
+
+// limitPrice returns the worst price which the order can trade at, 0 if the market order is not protected
+func (o Order) limitPrice() int {
+	if o.PriceMode == Market {
+		return o.ProtectionPrice
+	}
+	return o.Price
+}
+
+// slice displays the first slice of the iceberg order before it rests and hides the rest of it
+func (o *Order) slice() {
+	if o.DisplayQty > 0 && o.Qty > o.DisplayQty {
+		o.HiddenQty += o.Qty - o.DisplayQty
+		o.Qty = o.DisplayQty
+	}
+}
+
+// replenish displays the next slice of the iceberg order from the hidden qty, the slice gets the time now
+func (o *Order) replenish(now time.Time) {
+	qty := o.DisplayQty
+	if o.HiddenQty < qty {
+		qty = o.HiddenQty
+	}
+	o.Qty, o.HiddenQty = qty, o.HiddenQty-qty
+	o.Time = now
+}
+
+// fill updates the filled qty and the average price of the order
+func (o *Order) fill(price, qty int) {
+	o.Qty -= qty
+	o.FilledQty += qty
+	o.notional += price * qty
+	o.AvgPrice = float64(o.notional) / float64(o.FilledQty)
 }
 
 func (o Order) String() string {
-	// order[id]:[side][price mode]-<price, qty, time, index>
+	// order[id]:[side][price mode][time in force]-<price, qty, filled qty, time, index>
 	return fmt.Sprintf(
-		"order[%s]:[%s][%s]-<[price]: %d, [qty]: %d, [index]: %d, [time]: %s>\n",
-		o.ID.String(), o.Side.String(), o.PriceMode.String(), o.Price, o.Qty, o.idx, o.Time,
+		"order[%s]:[%s][%s][%s]-<[price]: %d, [qty]: %d, [filled]: %d/%d, [index]: %d, [time]: %s>\n",
+		o.ID.String(), o.Side.String(), o.PriceMode.String(), o.TimeInForce.String(), o.Price, o.Qty, o.FilledQty, o.OriginalQty, o.idx, o.Time,
 	)
 }
 
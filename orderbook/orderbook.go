@@ -1,25 +1,177 @@
 package orderbook
 
 import (
-	"container/heap"
 	"context"
 	"errors"
 	"fmt"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/google/uuid"
 )
 
-// OrderBook is the main structure of orderbook
+// OrderBook is the main structure of orderbook, it is owned by its matching loop which applies the commands one by
+// one, and the resting orders are read from the published view of the latest version of the orderbook
 type OrderBook struct {
+	// version is increased by the matching loop after each batch of commands, it is the first field for the
+	// alignment of the atomic operations
+	version uint64
+	// RWMutex is held by the matching loop when it applies the commands
 	sync.RWMutex
 	// Done saves the orders are matched
 	Done map[string]Order
-	Bids Orders
-	Asks Orders
+	// Canceled saves the orders are canceled with their remaining qty
+	Canceled map[string]Order
+	// Fills is the trade history, Done is derived from it
+	Fills []Fill
+	// bids & asks are the resting orders which are grouped by price levels
+	bids *bookSide
+	asks *bookSide
+	// stops are the untriggered stop orders, lastPrice is the last trade price which triggers them
+	stops     *triggerBook
+	lastPrice int
+	// positions are the net positions of the accounts by the fills
+	positions map[string]int
+
+	// maxQueueSize is the max. number of the resting orders of each side, orderExpiration is the live time of the
+	// orders and the history
+	maxQueueSize    int
+	orderExpiration time.Duration
+	cleanTimeFreq   time.Duration
+	// sessionEnd is the offset of the end of the trading session from midnight (UTC)
+	sessionEnd time.Duration
+	// halted rejects the new orders if it is true
+	halted bool
+	// instrument is the spec. of the prices and qty of the orders
+	instrument Instrument
+	// stp is the default mode of the self-trade prevention
+	stp STP
+	// slippage is the default max. slippage of the market orders in basis points
+	slippage int
+	// ledger holds the funds of the orders and settles the fills, replaying is true if the commands are replayed
+	// from the journal and the ledger is not changed by them
+	ledger    *Ledger
+	replaying bool
+	// rejected are the reasons of the stop orders which are rejected at their activation in the replayed commands
+	rejected map[string]string
+	// riskChecks is the chain of the risk checks of the new orders, riskConfig is the limits of them
+	riskChecks []RiskCheck
+	riskConfig RiskConfig
+
+	// marketData is the feed of the market data, seq is the sequence number of the last market data
+	marketData *feed[MarketData]
+	seq        uint64
+	// lastTop is the top of the orderbook of the last market data
+	lastTop TopOfBook
+	// trades are the fills which are not published yet
+	trades []Fill
+
+	// executions is the feed of the execution reports, execSeq is the sequence number of the last report
+	executions *feed[ExecutionReport]
+	execSeq    uint64
+
+	// journal is the write-ahead log of the commands, nil if the orderbook is not journaled,
+	// journalSeq is the sequence number of the last command of the journal
+	journal    *Journal
+	journalSeq uint64
+
+	// snapshotPath is the path of the snapshot, snapshotInterval is the interval of taking snapshots
+	snapshotPath     string
+	snapshotInterval time.Duration
+
+	// now and newID are the source of the time and ids of the orders, the fills are derived from the orders
+	now   Clock
+	newID IDGenerator
+
+	// cmds is the queue of the commands of the matching loop, the loop is stopped by stop
+	cmds      chan command
+	stop      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+	// view is the latest *bookView which is built by the readers, state is the bookState which is published by
+	// the matching loop after each batch
+	view  atomic.Value
+	state atomic.Value
+	// index is the copy of the orders by id, touched are the orders which are changed by the batch and they are
+	// copied into the index after it, reindex rebuilds the whole index after the orderbook is restored
+	index   orderIndex
+	touched map[uuid.UUID]struct{}
+	reindex bool
+}
+
+// WithSessionEnd is an option for the end of the trading session which is the offset from midnight (UTC),
+// the Day orders are expired at the end of the session
+func WithSessionEnd(offset time.Duration) Option {
+	return func(ob *OrderBook) error {
+		if offset < 0 || offset >= 24*time.Hour {
+			return ErrBadSessionEnd
+		}
+		ob.sessionEnd = offset
+		return nil
+	}
+}
+
+// WithClock is an option for the clock of the orderbook, the time of the orders, fills and expiration is
+// from the clock. The clock should be safe for concurrent use.
+func WithClock(clock Clock) Option {
+	return func(ob *OrderBook) error {
+		if clock == nil {
+			return ErrBadClock
+		}
+		ob.now = clock
+		return nil
+	}
+}
+
+// WithIDGenerator is an option for the id generator of the orders of the orderbook, the same commands
+// with the same clock and id generator always produce the same orderbook. The generator should be safe for
+// concurrent use.
+func WithIDGenerator(gen IDGenerator) Option {
+	return func(ob *OrderBook) error {
+		if gen == nil {
+			return ErrBadIDGenerator
+		}
+		ob.newID = gen
+		return nil
+	}
+}
+
+// WithInstrumentSpec is an option for the spec. of the prices and qty of the orderbook, the orders which are not on the
+// tick and lot of the instrument are rejected
+func WithInstrumentSpec(inst Instrument) Option {
+	return func(ob *OrderBook) error {
+		if err := inst.Validate(); err != nil {
+			return err
+		}
+		ob.instrument = inst
+		return nil
+	}
+}
 
-	//maxQueueSize  int
-	cleanTimeFreq time.Duration
+// WithMaxQueueSize is an option for the max. number of the resting orders of each side, the new orders are
+// rejected if the queue is full. It is DefaultMaxQueueSize if the option is not given.
+func WithMaxQueueSize(size int) Option {
+	return func(ob *OrderBook) error {
+		if size < 1 {
+			return ErrBadQueueSize
+		}
+		ob.maxQueueSize = size
+		return nil
+	}
+}
+
+// WithOrderExpiration is an option for the live time of the orders and the history, they are removed by the
+// auto-cleaner after it. It is DefaultOrderExpiration if the option is not given.
+func WithOrderExpiration(expiration time.Duration) Option {
+	return func(ob *OrderBook) error {
+		if expiration <= 0 {
+			return ErrBadOrderExpiration
+		}
+		ob.orderExpiration = expiration
+		return nil
+	}
 }
 
 // WithCleanTimeFrequecy is an option for the frequecy of the cleaning the expiration of the auto-cleaner
@@ -34,10 +186,21 @@ func WithCleanTimeFrequecy(duration time.Duration) Option {
 func New(opts ...Option) (*OrderBook, error) {
 
 	ob := &OrderBook{
-		Done:          make(map[string]Order),
-		Bids:          make(Orders, 0, MaxQueueSize),
-		Asks:          make(Orders, 0, MaxQueueSize),
-		cleanTimeFreq: 10 * time.Second,
+		Done:            make(map[string]Order),
+		Canceled:        make(map[string]Order),
+		Fills:           make([]Fill, 0),
+		bids:            newBookSide(Buy),
+		asks:            newBookSide(Sell),
+		stops:           newTriggerBook(),
+		positions:       make(map[string]int),
+		marketData:      newFeed[MarketData](),
+		executions:      newFeed[ExecutionReport](),
+		cleanTimeFreq:   10 * time.Second,
+		maxQueueSize:    DefaultMaxQueueSize,
+		orderExpiration: DefaultOrderExpiration,
+		instrument:      DefaultInstrument,
+		now:             time.Now,
+		newID:           uuid.New,
 	}
 
 	for _, opt := range opts {
@@ -45,42 +208,125 @@ func New(opts ...Option) (*OrderBook, error) {
 			return nil, err
 		}
 	}
+
+	if ob.riskChecks == nil {
+		ob.riskChecks = DefaultRiskChecks()
+	}
+	if ob.ledger != nil {
+		if err := ob.ledger.register(ob.instrument); err != nil {
+			return nil, err
+		}
+	}
+
+	ob.cmds = make(chan command, commandQueueSize)
+	ob.stop, ob.stopped = make(chan struct{}), make(chan struct{})
+	ob.index.orders = make(map[uuid.UUID]orderEntry)
+	ob.touched = make(map[uuid.UUID]struct{})
+	ob.state.Store(ob.buildState())
+	ob.view.Store(ob.buildView())
+	go ob.run()
 	return ob, nil
 }
 
+// Halt halts the trading, the new orders are rejected but the resting orders can be canceled
+func (ob *OrderBook) Halt() {
+	ob.exec(func() error {
+		ob.halted = true
+		return nil
+	})
+}
+
+// Resume resumes the trading
+func (ob *OrderBook) Resume() {
+	ob.exec(func() error {
+		ob.halted = false
+		return nil
+	})
+}
+
+// Halted checks if the trading is halted
+func (ob *OrderBook) Halted() bool {
+	return ob.loadState().halted
+}
+
+// Instrument returns the spec. of the prices and qty of the orderbook
+func (ob *OrderBook) Instrument() Instrument {
+	return ob.instrument
+}
+
 // Info prints the information of the orderbook
 func (ob *OrderBook) Info() {
-	bids, asks := ob.GetBids(), ob.GetAsks()
+	v := ob.loadState()
+	ob.RLock()
+	done, canceled, fills := len(ob.Done), len(ob.Canceled), len(ob.Fills)
+	ob.RUnlock()
+
 	log.Println("===> Orderbook settings...")
-	fmt.Printf("[Bids] orders: %d, available: %d\n", len(bids), cap(bids))
-	fmt.Printf("[Asks] orders: %d, available: %d\n", len(asks), cap(asks))
-	fmt.Printf("[Complete Order]: %d\n", len(ob.GetCompleteOrders()))
+	fmt.Printf("[Bids] orders: %d, available: %d\n", v.bidLen, v.maxQueueSize-v.bidLen)
+	fmt.Printf("[Asks] orders: %d, available: %d\n", v.askLen, v.maxQueueSize-v.askLen)
+	fmt.Printf("[Complete Order]: %d\n", done)
+	fmt.Printf("[Canceled Order]: %d\n", canceled)
+	fmt.Printf("[Fills]: %d\n", fills)
+	fmt.Printf("[Halted]: %t\n", v.halted)
+	fmt.Printf("[Max. size of the queue]: %d\n", v.maxQueueSize)
+	fmt.Printf("[Order live time]: %v\n", v.orderExpiration)
+	fmt.Printf("[Instrument]: %s\n", ob.instrument)
 	log.Println("... Orderbook information <===")
 }
 
-// GetBids returns bids orders
+// GetBids returns the copy of bids orders by priority
 func (ob *OrderBook) GetBids() Orders {
-	ob.RLock()
-	defer ob.RUnlock()
-	return ob.Bids
+	return copyOrders(ob.loadView().bids)
 }
 
-// GetAsks returns asks orders
+// GetAsks returns the copy of asks orders by priority
 func (ob *OrderBook) GetAsks() Orders {
-	ob.RLock()
-	defer ob.RUnlock()
-	return ob.Asks
+	return copyOrders(ob.loadView().asks)
 }
 
-// CheckQueueSize checks the size is less than the MaxQueueSize
+// Depth returns the top price levels of each side, all the price levels are returned if levels < 1
+func (ob *OrderBook) Depth(levels int) Depth {
+	v := ob.loadView()
+	return Depth{Bids: topLevels(v.bidLevels, levels), Asks: topLevels(v.askLevels, levels)}
+}
+
+// topLevels returns the copy of the top price levels, all the price levels are returned if n < 1
+func topLevels(levels []Level, n int) []Level {
+	if n < 1 || n > len(levels) {
+		n = len(levels)
+	}
+	top := make([]Level, n)
+	copy(top, levels)
+	return top
+}
+
+// copyOrders returns the copy of the orders
+func copyOrders(orders []*Order) Orders {
+	copies := make(Orders, len(orders))
+	for i, o := range orders {
+		c := *o
+		copies[i] = &c
+	}
+	return copies
+}
+
+// CheckQueueSize checks the size is less than the max. size of the queue
 func (ob *OrderBook) CheckQueueSize(side Side) error {
+	if v := ob.loadState(); v.sideLen(side) >= v.maxQueueSize {
+		return ErrTooLargeSizeOfQueue
+	}
+	return nil
+}
+
+// checkQueueSize checks the size is less than the max. size of the queue without lock
+func (ob *OrderBook) checkQueueSize(side Side) error {
 	switch side {
 	case Buy:
-		if len(ob.GetBids()) >= MaxQueueSize {
+		if ob.bids.Len() >= ob.maxQueueSize {
 			return ErrTooLargeSizeOfQueue
 		}
 	case Sell:
-		if len(ob.GetAsks()) >= MaxQueueSize {
+		if ob.asks.Len() >= ob.maxQueueSize {
 			return ErrTooLargeSizeOfQueue
 		}
 	default:
@@ -90,100 +336,344 @@ func (ob *OrderBook) CheckQueueSize(side Side) error {
 }
 
 // ProcessLimitOrder processes limit order and returns order id
-func (ob *OrderBook) ProcessLimitOrder(side Side, price, qty int) (string, error) {
-	if err := ob.CheckQueueSize(side); err != nil {
-		return "", err
-	}
-
+func (ob *OrderBook) ProcessLimitOrder(side Side, price, qty int, opts ...OrderOption) (string, error) {
 	// create order
-	newOrder, err := NewOrder(side, price, qty)
+	order, err := newOrder(ob.newID(), ob.now(), side, price, qty, opts...)
 	if err != nil {
 		return "", err
 	}
-	newOrder.PriceMode = Limit // set price mode
+	order.PriceMode = Limit // set price mode
+	if err := ob.instrument.checkOrder(order); err != nil {
+		return "", err
+	}
 	// trade
-	if err := ob.process(newOrder); err != nil {
-		return "", nil
+	if err := ob.process(order); err != nil {
+		return "", err
 	}
-	return newOrder.ID.String(), nil
+	return order.ID.String(), nil
 }
 
 // ProcessMarketOrder processes market order and returns order id
-func (ob *OrderBook) ProcessMarketOrder(side Side, qty int) (string, error) {
-	if err := ob.CheckQueueSize(side); err != nil {
+func (ob *OrderBook) ProcessMarketOrder(side Side, qty int, opts ...OrderOption) (string, error) {
+	order, err := newOrder(ob.newID(), ob.now(), side, 1, qty, opts...)
+	if err != nil {
 		return "", err
 	}
-	// Hint: 1 is the default price if there is no 'price' before
-	// should I make the default price configurable?
-	newOrder, err := NewOrder(side, 1, qty)
-	if err != nil {
+	// the market order has no price, it follows the prices of its fills
+	order.Price = 0
+	order.PriceMode = Market // set price mode
+	if order.PostOnly != PostOnlyNone {
+		return "", ErrPostOnlyMarket
+	}
+	if err := ob.instrument.checkOrder(order); err != nil {
 		return "", err
 	}
-	newOrder.PriceMode = Market // set price mode
 
 	// trade
-	if err := ob.process(newOrder); err != nil {
-		return "", nil
+	if err := ob.process(order); err != nil {
+		return "", err
 	}
-	return newOrder.ID.String(), nil
+	return order.ID.String(), nil
 }
 
-// process process order
+// process process order, the rejected order is reported with the reason
 func (ob *OrderBook) process(o *Order) error {
+	if o.TimeInForce == Day {
+		o.ExpireTime = ob.nextSessionEnd(o.Time)
+	}
+
+	return ob.exec(func() error {
+		if ob.halted {
+			return ob.reject(o, ErrTradingHalted)
+		}
+		if err := ob.checkRisk(o); err != nil {
+			return ob.reject(o, err)
+		}
+		// the stop order is checked when it is triggered
+		if !o.untriggered() {
+			if err := ob.admit(o); err != nil {
+				return ob.reject(o, err)
+			}
+		}
+		if err := ob.writeJournal(journalEntry{Type: journalSubmit, Order: o}); err != nil {
+			ob.release(o, true)
+			return ob.reject(o, err)
+		}
+
+		ob.report(ExecNew, o, restingStatus(o))
+		return ob.submit(o)
+	})
+}
+
+// admit checks if the order can be traded before it is journaled, the post-only order may be repriced and the
+// reduce-only order may be reduced, it should be called with lock
+func (ob *OrderBook) admit(o *Order) error {
+	if err := ob.checkQueueSize(o.Side); err != nil {
+		return err
+	}
+	if o.PostOnly != PostOnlyNone {
+		price, err := ob.postOnlyPrice(o.Side, o.Price, o.PostOnly)
+		if err != nil {
+			return err
+		}
+		o.Price = price
+	}
+	if o.ReduceOnly {
+		if err := ob.reduceOnly(o); err != nil {
+			return err
+		}
+	}
+	if o.PriceMode == Market {
+		ob.protect(o)
+	}
+	// the fill or kill order is rejected before trading if it can not be filled completely
+	if o.TimeInForce == FOK && ob.fillableQty(o) < o.Qty {
+		return ErrOrderNotFilled
+	}
+	// the market order is rejected if it can not reach any resting order
+	if o.PriceMode == Market && !ob.reachable(o) {
+		return ErrNoLiquidity
+	}
+	// the funds are held after all the checks
+	return ob.reserve(o)
+}
+
+// postOnlyPrice returns the price of the post-only order which does not cross the spread, the order is rejected
+// or slid one tick away from the best opposite price by the mode, it should be called with lock
+func (ob *OrderBook) postOnlyPrice(side Side, price int, mode PostOnly) (int, error) {
+	n := ob.opposite(side).levels.first()
+	if n == nil || !ob.cmp(side, n.price, price) {
+		return price, nil
+	}
+	if mode == PostOnlyReject {
+		return 0, ErrPostOnlyWouldTrade
+	}
+
+	tick := ob.instrument.TickSize
+	if side == Buy {
+		price = n.price - tick
+	} else {
+		price = n.price + tick
+	}
+	if price < 1 {
+		return 0, ErrPostOnlyWouldTrade
+	}
+	return price, nil
+}
 
-	// copy the origin qty
-	copyQty := o.Qty
+// nextSessionEnd returns the end of the session after the time t
+func (ob *OrderBook) nextSessionEnd(t time.Time) time.Time {
+	t = t.UTC()
+	end := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Add(ob.sessionEnd)
+	if !end.After(t) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// processOrder trades the order and pushes the rest of it without lock
+func (ob *OrderBook) processOrder(o *Order) error {
 	// trade
-	if err := ob.Trade(o); err != nil {
+	if err := ob.trade(o); err != nil {
 		return err
 	}
-	// save the new order if complete
+
+	// the order which is stopped by the self-trade prevention is canceled even if it is decremented to 0
+	if o.selfTraded {
+		o.selfTraded = false
+		ob.release(o, true)
+		ob.Canceled[o.ID.String()] = *o
+		ob.reportReason(ExecCanceled, o, StatusCanceled, fmt.Sprintf("%s: %s", ErrSelfTrade, ob.stpMode(o)))
+		return nil
+	}
+
+	// the complete order is saved by its fills
 	if o.Qty == 0 {
-		o.Qty = copyQty
-		ob.save(o)
-	} else { // otherwise, push this order to the queue
-		ob.PushOrderSync(o)
+		return nil
+	}
+
+	// the market order never rests, the rest of it is canceled
+	if o.PriceMode == Market {
+		ob.release(o, true)
+		ob.Canceled[o.ID.String()] = *o
+		ob.reportReason(ExecCanceled, o, StatusCanceled, ErrMarketNotFilled.Error())
+		return nil
+	}
+
+	// cancel the rest of the immediate or cancel order, the fill or kill order and the reduce-only order which closes
+	// the position, otherwise push this order to the queue
+	if o.TimeInForce == IOC || o.TimeInForce == FOK || (o.ReduceOnly && ob.reducible(o) == 0) {
+		ob.release(o, true)
+		ob.Canceled[o.ID.String()] = *o
+		ob.report(ExecCanceled, o, StatusCanceled)
+	} else {
+		ob.release(o, false)
+		o.slice()
+		ob.PushOrder(o)
 	}
 	return nil
 }
 
-// PushOrderSync pushes order into queue by side with lock
+// fillableQty returns the qty of the orders in the side queue which can be traded with the order, the qty is up to
+// the qty of the order
+func (ob *OrderBook) fillableQty(o *Order) int {
+	qty := 0
+	ob.matchable(o, o.Qty, func(price, fillQty int) {
+		qty += fillQty
+	})
+	return qty
+}
+
+// reachable checks if the order reaches any resting order which is not expired in the side queue, the resting order
+// of the same account is reached as well since the self-trade prevention is applied to it
+func (ob *OrderBook) reachable(o *Order) bool {
+	found := false
+	ob.opposite(o.Side).walk(func(l *priceLevel) bool {
+		if limit := o.limitPrice(); limit > 0 && !ob.cmp(o.Side, l.price, limit) {
+			return false
+		}
+		for e := l.orders.Front(); e != nil && !found; e = e.Next() {
+			found = !e.Value.(*Order).pastExpireTime(o.Time)
+		}
+		return !found
+	})
+	return found
+}
+
+// matchable calls fn with the price and qty of each fill which the order with the qty would get by trading with the
+// side queue now, without changing the orderbook. It skips the resting orders as trade does: the expired ones, the
+// ones which are canceled by the self-trade prevention and the reduce-only ones which can not reduce the position,
+// and it stops where trade cancels the rest of the order. It should be called with lock.
+func (ob *OrderBook) matchable(o *Order, qty int, fn func(price, qty int)) {
+	mode := ob.stpMode(o)
+	// the positions are changed by the fills before the reduce-only orders are reached
+	var positions map[string]int
+	reducible := func(r *Order) int {
+		pos, exist := positions[r.Account]
+		if !exist {
+			pos = ob.positions[r.Account]
+		}
+		return reducibleQty(r.Side, pos)
+	}
+	position := func(r *Order, fillQty int) {
+		if len(r.Account) == 0 {
+			return
+		}
+		if positions == nil {
+			positions = make(map[string]int)
+		}
+		if _, exist := positions[r.Account]; !exist {
+			positions[r.Account] = ob.positions[r.Account]
+		}
+		if r.Side == Sell {
+			fillQty = -fillQty
+		}
+		positions[r.Account] += fillQty
+	}
+
+	ob.opposite(o.Side).walk(func(l *priceLevel) bool {
+		if limit := o.limitPrice(); limit > 0 && !ob.cmp(o.Side, l.price, limit) {
+			return false
+		}
+		// the hidden qty of the iceberg orders can be traded at the same price as well
+		for e := l.orders.Front(); e != nil && qty > 0; e = e.Next() {
+			pop := e.Value.(*Order)
+			if pop.pastExpireTime(o.Time) {
+				continue
+			}
+			popQty := pop.Qty + pop.HiddenQty
+			if mode != STPNone && selfTrade(pop, o) {
+				switch mode {
+				case STPCancelOldest:
+					continue
+				case STPDecrementCancel:
+					if popQty < qty {
+						qty -= popQty
+						continue
+					}
+				}
+				qty = 0
+				break
+			}
+			if pop.ReduceOnly {
+				if allowed := reducible(pop); allowed < popQty {
+					popQty = allowed
+				}
+			}
+			fillQty := qty
+			if popQty < fillQty {
+				fillQty = popQty
+			}
+			if o.ReduceOnly {
+				if allowed := reducible(o); allowed < fillQty {
+					fillQty = allowed
+					qty = fillQty
+				}
+			}
+			if fillQty == 0 {
+				continue
+			}
+			fn(l.price, fillQty)
+			position(pop, fillQty)
+			position(o, fillQty)
+			qty -= fillQty
+		}
+		return qty > 0
+	})
+}
+
+// PushOrderSync pushes order into queue by side in the matching loop
 func (ob *OrderBook) PushOrderSync(o *Order) {
-	ob.Lock()
-	defer ob.Unlock()
-	ob.PushOrder(o)
+	ob.exec(func() error {
+		ob.PushOrder(o)
+		return nil
+	})
 }
 
 // PushOrder pushes order into the queue by side
 func (ob *OrderBook) PushOrder(o *Order) {
-	if o.Side == Buy {
-		heap.Push(&ob.Bids, o)
-	} else {
-		heap.Push(&ob.Asks, o)
-	}
+	ob.own(o.Side).push(o)
+	ob.touch(o.ID)
 }
 
-// PopBySide pops order by side
+// PopBySide pops the order with the highest priority from the opposite side of the side, nil if it is empty
 func (ob *OrderBook) PopBySide(side Side) *Order {
+	q := ob.opposite(side)
+	pop := q.best()
+	if pop != nil {
+		q.remove(pop)
+		ob.touch(pop.ID)
+	}
+	return pop
+}
+
+// own returns the queue of the side
+func (ob *OrderBook) own(side Side) *bookSide {
 	if side == Buy {
-		return heap.Pop(&ob.Asks).(*Order)
+		return ob.bids
 	}
-	return heap.Pop(&ob.Bids).(*Order)
+	return ob.asks
 }
 
-// GetSideQueueLenSync returns length of queue by side with lock
+// opposite returns the queue of the opposite side of the side
+func (ob *OrderBook) opposite(side Side) *bookSide {
+	if side == Buy {
+		return ob.asks
+	}
+	return ob.bids
+}
+
+// GetSideQueueLenSync returns length of queue by side from the state of the last batch
 func (ob *OrderBook) GetSideQueueLenSync(side Side) int {
-	ob.RLock()
-	defer ob.RUnlock()
-	return ob.GetSideQueueLen(side)
+	if side == Buy {
+		return ob.loadState().askLen
+	}
+	return ob.loadState().bidLen
 }
 
 // GetSideQueueLen returns length of queue by side
 func (ob *OrderBook) GetSideQueueLen(side Side) int {
-	if side == Buy {
-		return ob.Asks.Len()
-	}
-	return ob.Bids.Len()
+	return ob.opposite(side).Len()
 }
 
 // cmp compares the relation of i and j by side
@@ -197,67 +687,95 @@ func (ob *OrderBook) cmp(side Side, i, j int) bool {
 	return i > j
 }
 
-// Trade exchanges the order and the order from the side queue
+// Trade exchanges the order and the order from the side queue in the matching loop
 func (ob *OrderBook) Trade(order *Order) error {
-	// no seller so return
-	if ob.GetSideQueueLenSync(order.Side) == 0 {
+	return ob.exec(func() error {
+		if err := ob.trade(order); err != nil {
+			return err
+		}
+		ob.triggerStops(order.Time)
 		return nil
-	}
+	})
+}
 
-	ob.Lock()
-	skips := make(Orders, 0)
-	completes := make(Orders, 0)
-	for ob.GetSideQueueLen(order.Side) > 0 && order.Qty > 0 {
-		pop := ob.PopBySide(order.Side)
-		popQty := pop.Qty // keep old version pop.qty
+// trade exchanges the order and the order from the side queue without lock
+func (ob *OrderBook) trade(order *Order) error {
+	q := ob.opposite(order.Side)
+	for order.Qty > 0 {
+		// the order with the highest priority is traded first, it stays in its price level
+		// until it is completely filled
+		pop := q.best()
+		if pop == nil {
+			break
+		}
 
-		// order follows the price from pop if the price mode of order is Market
-		if order.PriceMode == Market {
-			order.Price = pop.Price
+		// the price levels are sorted, so the rest of them can not be traded either. The market order sweeps
+		// the price levels up to its protection price.
+		if limit := order.limitPrice(); limit > 0 && !ob.cmp(order.Side, pop.Price, limit) {
+			break
 		}
 
-		// pop follows the price from order if the price mode of pop is Market
-		if pop.PriceMode == Market {
-			pop.Price = order.Price
+		// the GTD or Day order which is expired before the auto-cleaner removes it is never traded, it is expired
+		// at the time of the command, so the replayed command expires it as well
+		if pop.pastExpireTime(order.Time) {
+			ob.cancel(q, pop, ExecExpired)
+			continue
 		}
 
-		// exchange
-		if ob.cmp(order.Side, pop.Price, order.Price) {
-			// exchange Qty
-			if pop.Qty == order.Qty {
-				pop.Qty = 0
-				order.Qty = 0
-			} else if pop.Qty > order.Qty {
-				pop.Qty = pop.Qty - order.Qty
-				order.Qty = 0
-			} else {
-				order.Qty = order.Qty - pop.Qty
-				pop.Qty = 0
+		// the orders of the same account do not trade with each other
+		if mode := ob.stpMode(order); mode != STPNone && selfTrade(pop, order) {
+			if ob.preventSelfTrade(q, pop, order, mode) {
+				break
 			}
+			continue
+		}
 
-			// push back pop if pop.Qty > 1 (pop order is not completely)
-			if pop.Qty > 1 {
-				ob.PushOrder(pop)
+		// exchange Qty
+		qty := order.Qty
+		if pop.Qty < qty {
+			qty = pop.Qty
+		}
+
+		// the reduce-only orders never increase the positions, the resting one is canceled if the position
+		// is closed and the taker stops trading
+		if pop.ReduceOnly {
+			allowed := ob.reducible(pop)
+			if allowed == 0 {
+				ob.cancel(q, pop, ExecCanceled)
+				continue
 			}
-			// collect the complete pop
-			if popQty != pop.Qty {
-				co := *pop
-				co.Qty = popQty - co.Qty
-				completes = append(completes, &co)
+			if allowed < qty {
+				qty = allowed
+			}
+		}
+		if order.ReduceOnly {
+			allowed := ob.reducible(order)
+			if allowed == 0 {
+				break
+			}
+			if allowed < qty {
+				qty = allowed
 			}
-		} else {
-			// collect the skipped pop
-			skips = append(skips, pop)
 		}
-	}
-	// push back the skip order into queue
-	for _, skip := range skips {
-		ob.PushOrder(skip)
-	}
-	ob.Unlock()
 
-	// saves the complete (pop) order
-	ob.save(completes...)
+		// the taker never pays more than its held funds, the rest of it is not filled
+		if qty = ob.payable(order, pop.Price, qty); qty == 0 {
+			break
+		}
+
+		fill := newFill(pop, order, qty)
+		q.fill(pop, fill.Price, qty)
+		order.fill(fill.Price, qty)
+		// the next slice of the iceberg order is queued behind the orders before the taker
+		if pop.Qty == 0 && pop.HiddenQty > 0 {
+			pop.replenish(order.Time)
+			q.push(pop)
+		}
+		// saves the fill
+		ob.save(fill, pop, order)
+		ob.reportFill(pop, fill)
+		ob.reportFill(order, fill)
+	}
 
 	return nil
 }
@@ -278,87 +796,277 @@ func (o *OrderBook) AutoCleanOrderQueue(ctx context.Context) {
 	}
 }
 
-// cleanOldOrder cleans the expiration orders
+// cleanOldOrder cleans the expiration orders in the matching loop
 func (o *OrderBook) cleanOldOrder() {
-	o.Lock()
-	defer o.Unlock()
+	o.exec(func() error {
+		o.removeExpired()
+		return nil
+	})
+}
 
-	// check if order is expired in Bids
-	for i, bid := range o.Bids {
-		if time.Since(bid.Time) > OrderExpiration {
-			o.Bids = append(o.Bids[:i], o.Bids[i+1:]...)
+// removeExpired removes the expiration orders and history without lock
+func (o *OrderBook) removeExpired() {
+	// check if order is expired in bids & asks
+	now := o.now()
+	for _, q := range []*bookSide{o.bids, o.asks} {
+		for _, order := range q.orders() {
+			if order.expired(now, o.orderExpiration) {
+				if err := o.writeJournal(journalEntry{Type: journalExpire, ID: order.ID.String()}); err != nil {
+					log.Println("orderbook: the expired order is kept, because:", err)
+					continue
+				}
+				o.cancel(q, order, ExecExpired)
+			}
 		}
 	}
-
-	// check if order is expired in Asks
-	for i, bid := range o.Asks {
-		if time.Since(bid.Time) > OrderExpiration {
-			o.Asks = append(o.Asks[:i], o.Asks[i+1:]...)
+	for _, order := range o.stops.orders() {
+		if order.expired(now, o.orderExpiration) {
+			if err := o.writeJournal(journalEntry{Type: journalExpire, ID: order.ID.String()}); err != nil {
+				log.Println("orderbook: the expired stop order is kept, because:", err)
+				continue
+			}
+			o.cancel(nil, order, ExecExpired)
 		}
 	}
 
 	// check if order is expired in Done
 	for k, v := range o.Done {
-		if time.Since(v.Time) > OrderExpiration {
+		if now.Sub(v.Time) > o.orderExpiration {
 			delete(o.Done, k)
+			o.touch(v.ID)
 		}
 	}
 
+	// check if fill is expired in the trade history, the fills are not always in time order (e.g. restored or
+	// replayed ones), so each of them is checked
+	fills := o.Fills[:0]
+	for _, f := range o.Fills {
+		if now.Sub(f.Time) <= o.orderExpiration {
+			fills = append(fills, f)
+		}
+	}
+	o.Fills = fills
+
+	// check if order is expired in Canceled
+	for k, v := range o.Canceled {
+		if now.Sub(v.Time) > o.orderExpiration {
+			delete(o.Canceled, k)
+			o.touch(v.ID)
+		}
+	}
 }
 
-// GetOrder gets order by id and returns the status of the order
-func (ob *OrderBook) GetOrder(id string, order *Order) (OrderStatus, error) {
+// CancelOrder cancels the resting order by id
+func (ob *OrderBook) CancelOrder(id string) error {
+	return ob.exec(func() error {
+		q, order := ob.findOrder(id)
+		if order == nil {
+			return ErrDataNotFound
+		}
+		if err := ob.writeJournal(journalEntry{Type: journalCancel, ID: id}); err != nil {
+			return err
+		}
+		ob.cancel(q, order, ExecCanceled)
+		return nil
+	})
+}
 
-	if order == nil {
-		return StatusUnknown, errors.New("order is nil")
+// findOrder returns the resting order and its queue by id, the order is nil if it does not exist.
+// The queue is nil if the order is the untriggered stop order.
+func (ob *OrderBook) findOrder(id string) (*bookSide, *Order) {
+	oid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil
 	}
+	for _, q := range []*bookSide{ob.bids, ob.asks} {
+		if order := q.get(oid); order != nil {
+			return q, order
+		}
+	}
+	return nil, ob.stops.get(oid)
+}
 
-	ob.RLock()
-	defer ob.RUnlock()
+// AmendOrder amends the price and qty of the resting order by id, the newQty is the new remaining qty of the order.
+// Reducing the qty keeps the time priority of the order, changing the price or increasing the qty loses
+// the time priority and the order is traded again. The price of the stop-market order is not changed and the newPrice 0
+// keeps the current price of the order. The amended order is checked by the risk checks before it is changed.
+func (ob *OrderBook) AmendOrder(id string, newPrice, newQty int) error {
+	if err := ob.instrument.checkQty(newQty); err != nil {
+		return err
+	}
+
+	return ob.exec(func() error {
+		if ob.halted {
+			return ErrTradingHalted
+		}
+
+		q, order := ob.findOrder(id)
+		if order == nil {
+			return ErrDataNotFound
+		}
+
+		price := newPrice
+		if order.PriceMode == Market || price == 0 {
+			price = order.Price
+		} else if err := ob.instrument.checkPrice(price); err != nil {
+			return err
+		}
+		// the new price of the post-only order should not cross the spread either
+		if order.PostOnly != PostOnlyNone && !order.untriggered() && price != order.Price {
+			var err error
+			if price, err = ob.postOnlyPrice(order.Side, price, order.PostOnly); err != nil {
+				return err
+			}
+		}
+
+		// the amended order is checked by the risk checks as a new order, but it is already one of the open orders
+		// of its account
+		now := ob.now()
+		if len(ob.riskChecks) > 0 {
+			amended := *order
+			amended.Price, amended.Qty, amended.HiddenQty, amended.Time = price, newQty, 0, now
+			s := ob.riskState(&amended)
+			s.OpenOrders--
+			if err := ob.runRiskChecks(s, &amended); err != nil {
+				return err
+			}
+		}
 
-	for _, bid := range ob.Bids {
-		if bid.ID.String() == id {
-			*order = *bid
-			return StatusPending, nil
+		// the funds of the amended order are held before it is changed
+		restore, err := ob.reserveAmend(order, price, newQty)
+		if err != nil {
+			return err
 		}
+
+		if err := ob.writeJournal(journalEntry{Type: journalAmend, ID: id, Price: price, Qty: newQty, Time: now}); err != nil {
+			restore()
+			return err
+		}
+		return ob.amend(q, order, price, newQty, now)
+	})
+}
+
+// amend amends the price and qty of the resting order without lock, the order loses its time priority and
+// gets the time now if it is not a reduction of the qty
+func (ob *OrderBook) amend(q *bookSide, order *Order, newPrice, newQty int, now time.Time) error {
+	// the untriggered stop order is amended in the trigger book
+	if q == nil {
+		stops := ob.stops.own(order.Side)
+		stops.remove(order)
+		order.Price = newPrice
+		order.Qty = newQty
+		order.OriginalQty = order.FilledQty + newQty
+		order.Time = now
+		stops.push(order)
+		ob.report(ExecReplaced, order, restingStatus(order))
+		return nil
 	}
 
-	for _, bid := range ob.Asks {
-		if bid.ID.String() == id {
-			*order = *bid
-			return StatusPending, nil
+	// keep the priority, the hidden qty of the iceberg order is reduced first
+	if newPrice == order.Price && newQty <= order.Qty+order.HiddenQty {
+		displayQty := order.Qty
+		if newQty < displayQty {
+			displayQty = newQty
 		}
+		order.HiddenQty = newQty - displayQty
+		q.resize(order, displayQty)
+		order.OriginalQty = order.FilledQty + newQty
+		ob.release(order, false)
+		ob.report(ExecReplaced, order, restingStatus(order))
+		return nil
 	}
 
-	if v, exist := ob.Done[id]; exist {
-		*order = v
-		return StatusCompleted, nil
+	// reset the priority and trade again
+	q.remove(order)
+	order.Price = newPrice
+	order.Qty = newQty
+	order.HiddenQty = 0
+	order.OriginalQty = order.FilledQty + newQty
+	order.Time = now
+	ob.report(ExecReplaced, order, restingStatus(order))
+	if err := ob.processOrder(order); err != nil {
+		return err
 	}
+	ob.triggerStops(now)
+	return nil
+}
 
-	return StatusCanceled, nil
+// cancel removes the order from the queue or the trigger book if the queue is nil, records it as canceled with
+// its remaining qty and reports it with the type (canceled or expired)
+func (ob *OrderBook) cancel(q *bookSide, order *Order, typ ExecType) {
+	if q == nil {
+		ob.stops.own(order.Side).remove(order)
+	} else {
+		q.remove(order)
+	}
+	ob.release(order, true)
+	ob.Canceled[order.ID.String()] = *order
+	ob.report(typ, order, StatusCanceled)
 }
 
-// save saves the completed orders
-func (o *OrderBook) save(orders ...*Order) {
-	o.Lock()
-	defer o.Unlock()
-	for _, order := range orders {
-		oid := order.ID.String()
-		if v, exist := o.Done[oid]; exist {
-			v.Price = order.Price
-			v.Qty += order.Qty
-			o.Done[oid] = v
-		} else {
-			o.Done[oid] = *order
-		}
+// GetOrder gets order by id and returns the status of the order
+func (ob *OrderBook) GetOrder(id string, order *Order) (OrderStatus, error) {
+
+	if order == nil {
+		return StatusUnknown, errors.New("order is nil")
+	}
+
+	// the orders which are changed by a batch are copied into the index before the results of the batch are
+	// returned, so the order can be read right after its command
+	oid, err := uuid.Parse(id)
+	if err != nil {
+		return StatusUnknown, ErrDataNotFound
 	}
+	ob.index.RLock()
+	e, exist := ob.index.orders[oid]
+	ob.index.RUnlock()
+	if exist {
+		*order = e.order
+		return e.status, nil
+	}
+
+	return StatusUnknown, ErrDataNotFound
+}
+
+// save saves the fill into the trade history and the done records of the maker & taker order without lock
+func (o *OrderBook) save(fill Fill, maker, taker *Order) {
+	o.lastPrice = fill.Price
+	o.position(maker, fill.Qty)
+	o.position(taker, fill.Qty)
+	o.settle(fill, maker, taker)
+	o.Fills = append(o.Fills, fill)
+	o.trades = append(o.trades, fill)
+	o.done(maker, fill)
+	o.done(taker, fill)
 }
 
-// GetCompleteOrders returns complete orders
+// done updates the done record of the order by the fill, the qty of the record is the total qty of the fills
+func (o *OrderBook) done(order *Order, fill Fill) {
+	oid := order.ID.String()
+	o.touch(order.ID)
+	v := *order
+	v.Price = fill.Price
+	v.Qty = fill.Qty
+	if prev, exist := o.Done[oid]; exist {
+		v.Qty += prev.Qty
+	}
+	o.Done[oid] = v
+}
+
+// GetCompleteOrders returns the copy of complete orders
 func (o *OrderBook) GetCompleteOrders() map[string]Order {
 	o.RLock()
 	defer o.RUnlock()
-	return o.Done
+	return copyHistory(o.Done)
+}
+
+// copyHistory returns the copy of the history of the orders
+func copyHistory(history map[string]Order) map[string]Order {
+	copies := make(map[string]Order, len(history))
+	for k, v := range history {
+		copies[k] = v
+	}
+	return copies
 }
 
 // GetCompleteOrder returns complete order by id
@@ -374,6 +1082,35 @@ func (o *OrderBook) GetCompleteOrder(id string, order *Order) error {
 	return nil
 }
 
+// GetTrades returns the trade history
+func (o *OrderBook) GetTrades() []Fill {
+	o.RLock()
+	defer o.RUnlock()
+	fills := make([]Fill, len(o.Fills))
+	copy(fills, o.Fills)
+	return fills
+}
+
+// GetOrderFills returns the fills of the order by id
+func (o *OrderBook) GetOrderFills(id string) []Fill {
+	o.RLock()
+	defer o.RUnlock()
+	fills := make([]Fill, 0)
+	for _, fill := range o.Fills {
+		if fill.MakerOrderID.String() == id || fill.TakerOrderID.String() == id {
+			fills = append(fills, fill)
+		}
+	}
+	return fills
+}
+
+// GetCanceledOrders returns the copy of canceled orders
+func (o *OrderBook) GetCanceledOrders() map[string]Order {
+	o.RLock()
+	defer o.RUnlock()
+	return copyHistory(o.Canceled)
+}
+
 /* version 1 for order exchange
 // TradeLimitBids trades the bid and ask orders with limit price
 func (ob *OrderBook) TradeLimitBids(order *Order) error {
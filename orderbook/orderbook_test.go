@@ -2,8 +2,13 @@ package orderbook
 
 import (
 	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 func TestOrderBook(t *testing.T) {
@@ -37,25 +42,22 @@ func TestOrderBook(t *testing.T) {
 		}
 	}
 
-	// add order with market type
-	mOrderID, err := ob.ProcessMarketOrder(Buy, 10)
-	if err != nil {
-		t.Fatal(err)
+	// the market order never rests, it is rejected if there is no order to trade
+	if _, err := ob.ProcessMarketOrder(Buy, 10); err != ErrNoLiquidity {
+		t.Fatal("wrong error type", err)
 	}
-	// check if the order is in the pending
-	mIdStatus, err := ob.GetOrder(mOrderID, &order)
+
+	ob.ProcessLimitOrder(Sell, 100, 5)
+	ob.ProcessLimitOrder(Sell, 101, 5)
+
+	// add order with market type, it sweeps the price levels
+	mOrderID, err := ob.ProcessMarketOrder(Buy, 10)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if mIdStatus != StatusPending {
-		t.Fatalf("the status of order[%s] should be: %s, but got %s", order.ID, StatusPending, mIdStatus)
-	}
-
-	ob.ProcessMarketOrder(Sell, 5)
-	ob.ProcessMarketOrder(Sell, 5)
 
 	// the status of the same order should be completely
-	mIdStatus, err = ob.GetOrder(mOrderID, &order)
+	mIdStatus, err := ob.GetOrder(mOrderID, &order)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -81,7 +83,9 @@ func TestOrderBookQTY(t *testing.T) {
 			price int
 			qty   int
 		}
-		want int
+		// focus is the index of the order to check
+		focus int
+		want  int
 	}{
 		{
 			orders: []struct {
@@ -116,12 +120,13 @@ func TestOrderBookQTY(t *testing.T) {
 				price int
 				qty   int
 			}{
-				{side: Buy, pm: Market, price: 1, qty: 10}, // focus on first order
 				{side: Sell, pm: Limit, price: 100, qty: 1},
 				{side: Sell, pm: Limit, price: 101, qty: 1},
 				{side: Sell, pm: Limit, price: 200, qty: 8},
+				{side: Buy, pm: Market, qty: 10}, // focus on the market order which sweeps the book
 			},
-			want: 10,
+			focus: 3,
+			want:  10,
 		},
 		{
 			orders: []struct {
@@ -130,12 +135,13 @@ func TestOrderBookQTY(t *testing.T) {
 				price int
 				qty   int
 			}{
-				{side: Sell, pm: Market, price: 1, qty: 10}, // focus on first order
 				{side: Buy, pm: Limit, price: 10, qty: 2},
 				{side: Buy, pm: Limit, price: 1000, qty: 1},
 				{side: Buy, pm: Limit, price: 299, qty: 6},
+				{side: Sell, pm: Market, qty: 10}, // focus on the market order which sweeps the book
 			},
-			want: 9,
+			focus: 3,
+			want:  9,
 		},
 	}
 
@@ -154,7 +160,7 @@ func TestOrderBookQTY(t *testing.T) {
 				if err != nil {
 					t.Fatal(err)
 				}
-				if i == 0 {
+				if i == tt.focus {
 					targetID = id
 				}
 			case Market:
@@ -163,13 +169,13 @@ func TestOrderBookQTY(t *testing.T) {
 				if err != nil {
 					t.Fatal(err)
 				}
-				if i == 0 {
+				if i == tt.focus {
 					targetID = id
 				}
 			}
-			if len(targetID) == 0 {
-				t.Fatal("targetID is empty")
-			}
+		}
+		if len(targetID) == 0 {
+			t.Fatal("targetID is empty")
 		}
 
 		// get complete order
@@ -195,7 +201,7 @@ func TestMaxSizeOfQueue(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	for i := 0; i < MaxQueueSize; i++ {
+	for i := 0; i < DefaultMaxQueueSize; i++ {
 		if _, err := ob.ProcessLimitOrder(Buy, 100, 10); err != nil {
 			t.Fatal(err)
 		}
@@ -205,7 +211,7 @@ func TestMaxSizeOfQueue(t *testing.T) {
 		t.Fatal("wrong error type", err)
 	}
 
-	for i := 0; i < MaxQueueSize; i++ {
+	for i := 0; i < DefaultMaxQueueSize; i++ {
 		ob.ProcessLimitOrder(Sell, 101, 10)
 	}
 
@@ -227,14 +233,14 @@ func TestDone(t *testing.T) {
 	}
 
 	// add buy with price: 100, qty: 10
-	for i := 0; i < MaxQueueSize; i++ {
+	for i := 0; i < DefaultMaxQueueSize; i++ {
 		if _, err := ob.ProcessLimitOrder(Buy, 100, 10); err != nil {
 			t.Fatal(err)
 		}
 	}
 
 	// add sell with price: 100, qty: 10
-	for i := 0; i < MaxQueueSize; i++ {
+	for i := 0; i < DefaultMaxQueueSize; i++ {
 		if _, err := ob.ProcessLimitOrder(Sell, 100, 10); err != nil {
 			t.Fatal(err)
 		}
@@ -260,9 +266,8 @@ func TestAutoCleanQueue(t *testing.T) {
 
 	t.Log("start testing clean expired order automatically...")
 
-	originOE := OrderExpiration
-	OrderExpiration = 100 * time.Millisecond // updated the expiration directly
-	ob, err := New(WithCleanTimeFrequecy(1 * time.Millisecond))
+	clock := newTestClock()
+	ob, err := New(WithCleanTimeFrequecy(1*time.Millisecond), WithClock(clock.Now))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -278,10 +283,32 @@ func TestAutoCleanQueue(t *testing.T) {
 		t.Fatal("the size of bids should be 1")
 	}
 
-	// enable auto clean queue, for test reason, I enable that after orders are enqueued
+	// the orders are not expired before the expiration
+	clock.Add(DefaultOrderExpiration)
+	ob.cleanOldOrder()
+	if len(ob.GetAsks()) != 1 || len(ob.GetBids()) != 1 {
+		t.Fatal("the orders should not be expired")
+	}
+
+	// enable auto clean queue, for test reason, I enable that after orders are enqueued,
+	// and wait for the reports of the expired orders instead of the ticks of the cleaner
+	expired := ob.SubscribeExecutions(ExecutionFilter{}, 8)
 	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel() // let the auto cleaner leave
 	go ob.AutoCleanOrderQueue(ctx)
-	time.Sleep(500 * time.Millisecond) // wait for cleaner
+
+	clock.Add(time.Second)
+	timeout := time.After(5 * time.Second)
+	for n := 0; n < 2; {
+		select {
+		case r := <-expired:
+			if r.Type == ExecExpired {
+				n++
+			}
+		case <-timeout:
+			t.Fatal("the orders should be expired by the auto cleaner")
+		}
+	}
 
 	if len(ob.GetAsks()) != 0 {
 		t.Fatal("the size of asks should be 0")
@@ -289,9 +316,104 @@ func TestAutoCleanQueue(t *testing.T) {
 	if len(ob.GetBids()) != 0 {
 		t.Fatal("the size of bids should be 0")
 	}
-	cancel() // let the auto cleaner leave
+	t.Log("... Passed")
+}
+
+// testClock is the clock which is moved by the test
+type testClock struct {
+	sync.Mutex
+	t time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{t: time.Date(2022, 9, 4, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *testClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+	return c.t
+}
+
+func (c *testClock) Add(d time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// sequentialIDs returns the id generator which generates the ids in sequence
+func sequentialIDs() IDGenerator {
+	var (
+		mu sync.Mutex
+		n  uint64
+	)
+	return func() uuid.UUID {
+		mu.Lock()
+		defer mu.Unlock()
+		n++
+		var id uuid.UUID
+		binary.BigEndian.PutUint64(id[8:], n)
+		return id
+	}
+}
+
+func TestDeterministicOrderBook(t *testing.T) {
+
+	t.Log("start testing the orderbook with the clock and the id generator...")
+
+	if _, err := New(WithClock(nil)); err != ErrBadClock {
+		t.Fatal("wrong error type", err)
+	}
+	if _, err := New(WithIDGenerator(nil)); err != ErrBadIDGenerator {
+		t.Fatal("wrong error type", err)
+	}
+
+	run := func() (*OrderBook, []Fill) {
+		clock := newTestClock()
+		ob, err := New(WithClock(clock.Now), WithIDGenerator(sequentialIDs()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 10; i++ {
+			clock.Add(time.Millisecond)
+			if _, err := ob.ProcessLimitOrder(Buy, 100-i%3, 10); err != nil {
+				t.Fatal(err)
+			}
+			clock.Add(time.Millisecond)
+			if _, err := ob.ProcessLimitOrder(Sell, 99+i%4, 7); err != nil {
+				t.Fatal(err)
+			}
+		}
+		clock.Add(time.Millisecond)
+		if _, err := ob.ProcessMarketOrder(Sell, 15); err != nil {
+			t.Fatal(err)
+		}
+		trades := ob.GetTrades()
+		clock.Add(DefaultOrderExpiration)
+		if _, err := ob.ProcessLimitOrder(Buy, 90, 1); err != nil {
+			t.Fatal(err)
+		}
+		clock.Add(time.Second)
+		ob.cleanOldOrder()
+		return ob, trades
+	}
 
-	OrderExpiration = originOE // resume the default setting of OrderExpiration
+	ob, want := run()
+	replayed, got := run()
+	if len(want) == 0 || len(want) != len(got) {
+		t.Fatalf("the number of the fills should be %d, but got %d", len(want), len(got))
+	}
+	for i := range want {
+		if want[i] != got[i] {
+			t.Fatalf("the fill[%d] should be %s, but got %s", i, want[i], got[i])
+		}
+	}
+	sameBook(t, ob, replayed)
+
+	// only the order after the expiration is kept
+	if bids := ob.GetBids(); len(bids) != 1 || bids[0].Price != 90 || ob.GetAsks().Len() != 0 {
+		t.Fatal("the orders should be expired by the clock", bids)
+	}
 	t.Log("... Passed")
 }
 
@@ -304,40 +426,634 @@ func TestTradePrice(t *testing.T) {
 		t.Fatal()
 	}
 
-	// 'market Sell' with price 1 because there is no price before
-	id, err := ob.ProcessMarketOrder(Sell, 10)
+	// 'market Sell' has no price, so it can not trade without the orders with limited price
+	if _, err := ob.ProcessMarketOrder(Sell, 10); err != ErrNoLiquidity {
+		t.Fatal("wrong error type", err)
+	}
+
+	// 'limit Buy'
+	for _, price := range []int{100, 101} {
+		if _, err := ob.ProcessLimitOrder(Buy, price, 5); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 'market Sell' trades at the prices of the limit orders, the rest of it is canceled
+	id, err := ob.ProcessMarketOrder(Sell, 15)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	// 'market Buy' with price 1 because there is no price before
-	if _, err := ob.ProcessMarketOrder(Buy, 5); err != nil {
+	var order Order
+	if err := ob.GetCompleteOrder(id, &order); err != nil {
 		t.Fatal(err)
 	}
+	if order.Price != 100 || order.Qty != 10 || order.AvgPrice != 100.5 {
+		t.Fatal("the market order should trade 10 at 101 & 100", order)
+	}
+	if status, _ := ob.GetOrder(id, &order); status != StatusCanceled || order.Qty != 5 {
+		t.Fatal("the rest of the market order should be canceled", status, order)
+	}
+	if len(ob.GetBids()) != 0 || len(ob.GetAsks()) != 0 {
+		t.Fatal("the market order should not rest")
+	}
+
+	t.Log("... Passed")
+
+}
+
+func TestCancelOrder(t *testing.T) {
+
+	t.Log("start testing cancel order...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make([]string, 0)
+	for _, price := range []int{100, 101, 99} {
+		id, err := ob.ProcessLimitOrder(Buy, price, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	// cancel the order in the middle of the queue
+	if err := ob.CancelOrder(ids[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if ob.GetBids().Len() != 2 {
+		t.Fatal("wrong size of bids:", ob.GetBids().Len(), "it should be 2")
+	}
 
 	var order Order
-	if err := ob.GetCompleteOrder(id, &order); err != nil {
+	status, err := ob.GetOrder(ids[0], &order)
+	if err != nil {
 		t.Fatal(err)
 	}
+	if status != StatusCanceled {
+		t.Fatalf("the status of order[%s] should be: %s, but got %s", ids[0], StatusCanceled, status)
+	}
+	if order.Qty != 10 {
+		t.Fatalf("the remaining qty of canceled order should be %d, but got %d", 10, order.Qty)
+	}
 
-	if order.Price != 1 {
-		t.Fatal("the price should be 1, becuase there is no exchage before")
+	// the order can not be canceled twice
+	if err := ob.CancelOrder(ids[0]); err != ErrDataNotFound {
+		t.Fatal("wrong error type", err)
 	}
 
-	// 'limit Buy'
-	price := 100
-	if _, err := ob.ProcessLimitOrder(Buy, price, 5); err != nil {
+	// the heap order should be kept after canceling
+	if _, err := ob.ProcessLimitOrder(Sell, 99, 5); err != nil {
 		t.Fatal(err)
 	}
+	var done Order
+	if err := ob.GetCompleteOrder(ids[1], &done); err != nil {
+		t.Fatal(err)
+	}
+	if done.Price != 101 {
+		t.Fatalf("the price should be %d, but got %d", 101, done.Price)
+	}
 
-	if err := ob.GetCompleteOrder(id, &order); err != nil {
+	// unknown order is not canceled
+	if status, err := ob.GetOrder("unknown", &order); err != ErrDataNotFound || status != StatusUnknown {
+		t.Fatalf("the status of unknown order should be: %s, but got %s (%v)", StatusUnknown, status, err)
+	}
+
+	t.Log("... Passed")
+}
+
+func TestAmendOrder(t *testing.T) {
+
+	t.Log("start testing amend order...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := ob.ProcessLimitOrder(Buy, 100, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := ob.ProcessLimitOrder(Buy, 100, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// reducing the qty keeps the priority
+	if err := ob.AmendOrder(first, 100, 5); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Sell, 100, 5); err != nil {
+		t.Fatal(err)
+	}
+	var order Order
+	if err := ob.GetCompleteOrder(first, &order); err != nil {
+		t.Fatal("the first order should be traded first", err)
+	}
+
+	// increasing the qty loses the priority
+	third, err := ob.ProcessLimitOrder(Buy, 100, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.AmendOrder(second, 100, 20); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Sell, 100, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.GetCompleteOrder(third, &order); err != nil {
+		t.Fatal("the third order should be traded before the amended order", err)
+	}
+
+	// changing the price trades the order again
+	ask, err := ob.ProcessLimitOrder(Sell, 110, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.AmendOrder(second, 110, 20); err != nil {
+		t.Fatal(err)
+	}
+	status, err := ob.GetOrder(ask, &order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != StatusCompleted {
+		t.Fatalf("the status of order[%s] should be: %s, but got %s", ask, StatusCompleted, status)
+	}
+
+	// the price 0 keeps the current price
+	fourth, err := ob.ProcessLimitOrder(Buy, 90, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.AmendOrder(fourth, 0, 15); err != nil {
+		t.Fatal(err)
+	}
+	if status, err := ob.GetOrder(fourth, &order); err != nil || status != StatusPending || order.Price != 90 || order.Qty != 15 {
+		t.Fatal("only the qty of the order should be amended", order, err)
+	}
+
+	// bad values and unknown order
+	if err := ob.AmendOrder(second, 100, 0); err != ErrBadOrderQty {
+		t.Fatal("wrong error type", err)
+	}
+	if err := ob.AmendOrder("unknown", 100, 10); err != ErrDataNotFound {
+		t.Fatal("wrong error type", err)
+	}
+
+	t.Log("... Passed")
+}
+
+func TestFills(t *testing.T) {
+
+	t.Log("start testing fills of the trading...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	low, err := ob.ProcessLimitOrder(Buy, 100, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	high, err := ob.ProcessLimitOrder(Buy, 101, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	taker, err := ob.ProcessLimitOrder(Sell, 100, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fills := ob.GetOrderFills(taker)
+	if len(fills) != 2 {
+		t.Fatalf("the number of fills should be %d, but got %d", 2, len(fills))
+	}
+
+	wants := []struct {
+		maker      string
+		price, qty int
+	}{
+		{maker: high, price: 101, qty: 5},
+		{maker: low, price: 100, qty: 3},
+	}
+	for i, want := range wants {
+		fill := fills[i]
+		if fill.MakerOrderID.String() != want.maker || fill.TakerOrderID.String() != taker {
+			t.Fatalf("wrong maker or taker of fill[%s]", fill.ID)
+		}
+		if fill.Price != want.price || fill.Qty != want.qty {
+			t.Fatalf("the price & qty of fill should be %d & %d, but got %d & %d", want.price, want.qty, fill.Price, fill.Qty)
+		}
+		if fill.Aggressor != Sell {
+			t.Fatalf("the aggressor side should be %s, but got %s", Sell, fill.Aggressor)
+		}
+	}
+
+	// the done record is derived from the fills
+	var order Order
+	if err := ob.GetCompleteOrder(taker, &order); err != nil {
+		t.Fatal(err)
+	}
+	if order.Qty != 8 || order.Price != 100 {
+		t.Fatalf("the qty & price of the done record should be %d & %d, but got %d & %d", 8, 100, order.Qty, order.Price)
+	}
+
+	if len(ob.GetTrades()) != 2 {
+		t.Fatalf("the size of trade history should be %d, but got %d", 2, len(ob.GetTrades()))
+	}
+
+	t.Log("... Passed")
+}
+
+func TestExpiredFills(t *testing.T) {
+
+	t.Log("start testing expired fills of the trade history...")
+
+	clock := newTestClock()
+	ob, err := New(WithOrderExpiration(time.Minute), WithClock(clock.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ob.ProcessLimitOrder(Buy, 100, 5)
+	ob.ProcessLimitOrder(Sell, 100, 5)
+	clock.Add(30 * time.Second)
+	ob.ProcessLimitOrder(Buy, 100, 5)
+	ob.ProcessLimitOrder(Sell, 100, 5)
+	if len(ob.Fills) != 2 {
+		t.Fatalf("the size of trade history should be %d, but got %d", 2, len(ob.Fills))
+	}
+
+	// the fills are not always in time order, e.g. the restored ones, so the newer one is put first
+	ob.Fills[0], ob.Fills[1] = ob.Fills[1], ob.Fills[0]
+	newer := ob.Fills[0].ID
+	clock.Add(45 * time.Second)
+	ob.cleanOldOrder()
+	if len(ob.Fills) != 1 || ob.Fills[0].ID != newer {
+		t.Fatal("only the expired fill should be removed", ob.Fills)
+	}
+
+	t.Log("... Passed")
+}
+
+func TestPartiallyFilledOrder(t *testing.T) {
+
+	t.Log("start testing partially filled order...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := ob.ProcessLimitOrder(Buy, 101, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Sell, 100, 3); err != nil {
+		t.Fatal(err)
+	}
+	// the second fill has the same price of the maker
+	if _, err := ob.ProcessLimitOrder(Sell, 101, 1); err != nil {
 		t.Fatal(err)
 	}
 
-	if order.Price != price {
-		t.Fatalf("the price should be %d, becuase there is an order with limited price ", price)
+	var order Order
+	status, err := ob.GetOrder(id, &order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != StatusPartiallyFilled {
+		t.Fatalf("the status of order[%s] should be: %s, but got %s", id, StatusPartiallyFilled, status)
+	}
+	if order.OriginalQty != 10 || order.FilledQty != 4 || order.RemainingQty() != 6 || order.Qty != 6 {
+		t.Fatalf("wrong qty of order: %s", order)
+	}
+	if order.AvgPrice != 101 {
+		t.Fatalf("the average price should be %d, but got %f", 101, order.AvgPrice)
+	}
+
+	// the taker with different prices of the makers
+	if _, err := ob.ProcessLimitOrder(Buy, 99, 10); err != nil {
+		t.Fatal(err)
+	}
+	taker, err := ob.ProcessLimitOrder(Sell, 99, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err = ob.GetOrder(taker, &order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != StatusCompleted {
+		t.Fatalf("the status of order[%s] should be: %s, but got %s", taker, StatusCompleted, status)
+	}
+	// 6 * 101 + 2 * 99
+	if order.FilledQty != 8 || order.RemainingQty() != 0 || order.AvgPrice != 100.5 {
+		t.Fatalf("wrong qty or average price of order: %s, %f", order, order.AvgPrice)
 	}
 
 	t.Log("... Passed")
+}
+
+func TestTimeInForce(t *testing.T) {
+
+	t.Log("start testing time in force of orders...")
+
+	clock := newTestClock()
+	ob, err := New(WithClock(clock.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ob.ProcessLimitOrder(Sell, 100, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	// the rest of IOC order is canceled
+	ioc, err := ob.ProcessLimitOrder(Buy, 100, 8, WithTimeInForce(IOC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order Order
+	status, err := ob.GetOrder(ioc, &order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != StatusCanceled || order.FilledQty != 5 || order.Qty != 3 {
+		t.Fatalf("the IOC order should be canceled with the remaining qty: %d, but got %s, %s", 3, status, order)
+	}
+	if ob.GetBids().Len() != 0 {
+		t.Fatal("the IOC order should not be in the queue")
+	}
+
+	// the FOK order is rejected if it can not be filled completely
+	if _, err := ob.ProcessLimitOrder(Sell, 100, 5); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 8, WithTimeInForce(FOK)); err != ErrOrderNotFilled {
+		t.Fatal("wrong error type", err)
+	}
+	if ob.GetAsks().Len() != 1 || ob.GetBids().Len() != 0 {
+		t.Fatal("the book should not be changed by the rejected FOK order")
+	}
+	fok, err := ob.ProcessLimitOrder(Buy, 100, 5, WithTimeInForce(FOK))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := ob.GetOrder(fok, &order); status != StatusCompleted {
+		t.Fatalf("the status of order[%s] should be: %s, but got %s", fok, StatusCompleted, status)
+	}
+
+	// the GTD order needs the expire time in the future
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 5, WithTimeInForce(GTD)); err != ErrBadExpireTime {
+		t.Fatal("wrong error type", err)
+	}
+	gtd, err := ob.ProcessLimitOrder(Buy, 100, 5, WithTimeInForce(GTD), WithExpireTime(clock.Now().Add(50*time.Millisecond)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gtc, err := ob.ProcessLimitOrder(Buy, 100, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Add(100 * time.Millisecond)
+	ob.cleanOldOrder()
+	if status, _ := ob.GetOrder(gtd, &order); status != StatusCanceled {
+		t.Fatalf("the status of order[%s] should be: %s, but got %s", gtd, StatusCanceled, status)
+	}
+	if status, _ := ob.GetOrder(gtc, &order); status != StatusPending {
+		t.Fatalf("the status of order[%s] should be: %s, but got %s", gtc, StatusPending, status)
+	}
+
+	t.Log("... Passed")
+}
+
+func TestExpiredOrderNotTraded(t *testing.T) {
+
+	t.Log("start testing the expired orders before the auto-cleaner...")
+
+	clock := newTestClock()
+	ob, err := New(WithClock(clock.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	gtd, err := ob.ProcessLimitOrder(Sell, 100, 5, WithTimeInForce(GTD), WithExpireTime(clock.Now().Add(time.Minute)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	day, err := ob.ProcessLimitOrder(Sell, 101, 5, WithTimeInForce(Day))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gtc, err := ob.ProcessLimitOrder(Sell, 102, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the orders are expired but the auto-cleaner does not run yet
+	clock.Add(24 * time.Hour)
+	if _, err := ob.ProcessLimitOrder(Buy, 110, 20, WithTimeInForce(FOK)); err != ErrOrderNotFilled {
+		t.Fatal("the expired orders should not be fillable", err)
+	}
+	taker, err := ob.ProcessLimitOrder(Buy, 110, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fills := ob.GetOrderFills(taker)
+	if len(fills) != 1 || fills[0].MakerOrderID.String() != gtc {
+		t.Fatal("the taker should only trade with the GTC order", fills)
+	}
+	var order Order
+	for _, id := range []string{gtd, day} {
+		if status, _ := ob.GetOrder(id, &order); status != StatusCanceled || order.FilledQty != 0 {
+			t.Fatalf("the order[%s] should be expired without fills, but got %s, %s", id, status, order)
+		}
+	}
 
+	t.Log("... Passed")
+}
+
+func TestDayOrder(t *testing.T) {
+
+	t.Log("start testing expiration of day orders...")
+
+	if _, err := New(WithSessionEnd(24 * time.Hour)); err != ErrBadSessionEnd {
+		t.Fatal("wrong error type", err)
+	}
+
+	ob, err := New(WithSessionEnd(16 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		now, want time.Time
+	}{
+		{
+			now:  time.Date(2022, 9, 4, 10, 0, 0, 0, time.UTC),
+			want: time.Date(2022, 9, 4, 16, 0, 0, 0, time.UTC),
+		},
+		{
+			now:  time.Date(2022, 9, 4, 16, 0, 0, 0, time.UTC),
+			want: time.Date(2022, 9, 5, 16, 0, 0, 0, time.UTC),
+		},
+		{
+			now:  time.Date(2022, 9, 4, 20, 0, 0, 0, time.UTC),
+			want: time.Date(2022, 9, 5, 16, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range testcases {
+		if got := ob.nextSessionEnd(tt.now); !got.Equal(tt.want) {
+			t.Fatalf("the end of session should be %s, but got %s", tt.want, got)
+		}
+	}
+
+	id, err := ob.ProcessLimitOrder(Buy, 100, 5, WithTimeInForce(Day))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order Order
+	if _, err := ob.GetOrder(id, &order); err != nil {
+		t.Fatal(err)
+	}
+	if order.ExpireTime.IsZero() || !order.ExpireTime.After(order.Time) {
+		t.Fatal("the expire time of day order should be the end of session", order.ExpireTime)
+	}
+
+	t.Log("... Passed")
+}
+
+func TestDepth(t *testing.T) {
+
+	t.Log("start testing depth of the orderbook...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orders := []struct {
+		side       Side
+		price, qty int
+	}{
+		{side: Buy, price: 100, qty: 10},
+		{side: Buy, price: 99, qty: 5},
+		{side: Buy, price: 100, qty: 20},
+		{side: Buy, price: 98, qty: 1},
+		{side: Sell, price: 102, qty: 7},
+		{side: Sell, price: 101, qty: 3},
+		{side: Sell, price: 101, qty: 4},
+	}
+	for _, o := range orders {
+		if _, err := ob.ProcessLimitOrder(o.side, o.price, o.qty); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the market order has no price level
+	if _, err := ob.ProcessMarketOrder(Sell, 30, WithTimeInForce(IOC)); err != nil {
+		t.Fatal(err)
+	}
+
+	depth := ob.Depth(2)
+	wantBids := []Level{{Price: 99, Qty: 5, Orders: 1}, {Price: 98, Qty: 1, Orders: 1}}
+	wantAsks := []Level{{Price: 101, Qty: 7, Orders: 2}, {Price: 102, Qty: 7, Orders: 1}}
+	if fmt.Sprint(depth.Bids) != fmt.Sprint(wantBids) {
+		t.Fatalf("the bids should be %v, but got %v", wantBids, depth.Bids)
+	}
+	if fmt.Sprint(depth.Asks) != fmt.Sprint(wantAsks) {
+		t.Fatalf("the asks should be %v, but got %v", wantAsks, depth.Asks)
+	}
+
+	// all the price levels
+	if _, err := ob.ProcessLimitOrder(Buy, 97, 1); err != nil {
+		t.Fatal(err)
+	}
+	if depth := ob.Depth(0); len(depth.Bids) != 3 || len(depth.Asks) != 2 {
+		t.Fatalf("wrong number of the price levels: %v", depth)
+	}
+
+	t.Log("... Passed")
+}
+
+func TestIcebergOrder(t *testing.T) {
+
+	t.Log("start testing the iceberg order...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	if _, err := ob.ProcessLimitOrder(Sell, 100, 30, WithDisplayQty(0)); err != ErrBadDisplayQty {
+		t.Fatal("wrong error type", err)
+	}
+	iceberg, err := ob.ProcessLimitOrder(Sell, 100, 30, WithDisplayQty(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := ob.ProcessLimitOrder(Sell, 100, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// only the displayed qty is in the depth
+	if asks := ob.Depth(1).Asks; len(asks) != 1 || asks[0].Qty != 20 || asks[0].Orders != 2 {
+		t.Fatal("the depth should show 20 of 2 orders", asks)
+	}
+
+	// the replenished slice loses its time priority
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 10); err != nil {
+		t.Fatal(err)
+	}
+	asks := ob.GetAsks()
+	if len(asks) != 2 || asks[0].ID.String() != plain || asks[1].ID.String() != iceberg {
+		t.Fatal("the replenished slice should be behind the plain order", asks)
+	}
+	if depth := ob.Depth(1).Asks; depth[0].Qty != 20 {
+		t.Fatal("the depth should show the new slice", depth)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 15); err != nil {
+		t.Fatal(err)
+	}
+	var order Order
+	status, err := ob.GetOrder(iceberg, &order)
+	if err != nil || status != StatusPartiallyFilled || order.Qty != 5 || order.HiddenQty != 10 || order.FilledQty != 15 {
+		t.Fatal("the iceberg order should show 5 and hide 10", status, err, order)
+	}
+
+	// the hidden qty can be traded by the fill or kill order
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 25, WithTimeInForce(FOK)); err != ErrOrderNotFilled {
+		t.Fatal("wrong error type", err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 15, WithTimeInForce(FOK)); err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := ob.GetOrder(iceberg, &order); status != StatusCompleted {
+		t.Fatal("the iceberg order should be completed", status)
+	}
+
+	// reducing the qty keeps the priority and reduces the hidden qty first
+	id, err := ob.ProcessLimitOrder(Buy, 90, 30, WithDisplayQty(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.AmendOrder(id, 90, 15); err != nil {
+		t.Fatal(err)
+	}
+	if ob.GetOrder(id, &order); order.Qty != 10 || order.HiddenQty != 5 {
+		t.Fatal("the iceberg order should show 10 and hide 5", order)
+	}
+	t.Log("... Passed")
 }
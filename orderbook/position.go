@@ -0,0 +1,65 @@
+package orderbook
+
+// WithReduceOnly is an option for the reduce-only order which can only shrink the position of its account, the
+// order is rejected if it can not reduce the position and its qty is up to the size of the position. The resting
+// reduce-only order is canceled when the position is closed by the other orders.
+func WithReduceOnly() OrderOption {
+	return func(o *Order) error {
+		o.ReduceOnly = true
+		return nil
+	}
+}
+
+// GetPosition returns the net position of the account, the position is long if it is greater than 0 and short if
+// it is less than 0
+func (ob *OrderBook) GetPosition(account string) int {
+	ob.RLock()
+	defer ob.RUnlock()
+	return ob.positions[account]
+}
+
+// position updates the position of the account of the order by the fill, it should be called with lock
+func (ob *OrderBook) position(o *Order, qty int) {
+	if len(o.Account) == 0 {
+		return
+	}
+	if o.Side == Sell {
+		qty = -qty
+	}
+	if pos := ob.positions[o.Account] + qty; pos != 0 {
+		ob.positions[o.Account] = pos
+	} else {
+		delete(ob.positions, o.Account)
+	}
+}
+
+// reducible returns the qty which can be traded by the reduce-only order without increasing the position of its
+// account, it should be called with lock
+func (ob *OrderBook) reducible(o *Order) int {
+	return reducibleQty(o.Side, ob.positions[o.Account])
+}
+
+// reducibleQty returns the qty of the side which reduces the position without increasing it
+func reducibleQty(side Side, pos int) int {
+	if side == Sell && pos > 0 {
+		return pos
+	}
+	if side == Buy && pos < 0 {
+		return -pos
+	}
+	return 0
+}
+
+// reduceOnly reduces the qty of the reduce-only order to the size of the position before it is traded,
+// it should be called with lock
+func (ob *OrderBook) reduceOnly(o *Order) error {
+	qty := ob.reducible(o)
+	if qty == 0 {
+		return ErrReduceOnly
+	}
+	if o.Qty > qty {
+		o.OriginalQty -= o.Qty - qty
+		o.Qty = qty
+	}
+	return nil
+}
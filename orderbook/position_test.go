@@ -0,0 +1,157 @@
+package orderbook
+
+import (
+	"testing"
+)
+
+func TestPostOnly(t *testing.T) {
+
+	t.Log("start testing the post-only order...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	if _, err := ob.ProcessLimitOrder(Sell, 101, 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 101, 10, WithPostOnly(PostOnlyReject)); err != ErrPostOnlyWouldTrade {
+		t.Fatal("wrong error type", err)
+	}
+	if _, err := ob.ProcessMarketOrder(Buy, 10, WithPostOnly(PostOnlyReject)); err != ErrPostOnlyMarket {
+		t.Fatal("wrong error type", err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 101, 10, WithPostOnly(PostOnly(9))); err != ErrBadPostOnly {
+		t.Fatal("wrong error type", err)
+	}
+
+	// the post-only order which does not cross the spread rests as the limit order
+	id, err := ob.ProcessLimitOrder(Buy, 99, 10, WithPostOnly(PostOnlyReject))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.AmendOrder(id, 101, 10); err != ErrPostOnlyWouldTrade {
+		t.Fatal("the amended price should not cross the spread", err)
+	}
+
+	// the slid orders are one tick away from the best opposite price
+	slid, err := ob.ProcessLimitOrder(Buy, 105, 10, WithPostOnly(PostOnlySlide))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order Order
+	if status, err := ob.GetOrder(slid, &order); err != nil || status != StatusPending || order.Price != 100 {
+		t.Fatal("the buy order should slide to 100", status, err, order)
+	}
+	if _, err := ob.ProcessLimitOrder(Sell, 90, 10, WithPostOnly(PostOnlySlide)); err != nil {
+		t.Fatal(err)
+	}
+	if asks := ob.Depth(1).Asks; len(asks) != 1 || asks[0].Price != 101 || asks[0].Qty != 20 {
+		t.Fatal("the sell order should slide to 101", asks)
+	}
+	if len(ob.GetTrades()) != 0 {
+		t.Fatal("the post-only orders should not trade")
+	}
+	t.Log("... Passed")
+}
+
+func TestReduceOnly(t *testing.T) {
+
+	t.Log("start testing the reduce-only order and the positions...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	if _, err := ob.ProcessLimitOrder(Sell, 100, 10, WithAccount("alice"), WithReduceOnly()); err != ErrReduceOnly {
+		t.Fatal("the reduce-only order without position should be rejected", err)
+	}
+
+	// alice is long 10 and bob is short 10
+	if _, err := ob.ProcessLimitOrder(Sell, 100, 10, WithAccount("bob")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 10, WithAccount("alice")); err != nil {
+		t.Fatal(err)
+	}
+	if alice, bob := ob.GetPosition("alice"), ob.GetPosition("bob"); alice != 10 || bob != -10 {
+		t.Fatalf("the positions should be 10 & -10, but got %d & %d", alice, bob)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 90, 10, WithAccount("alice"), WithReduceOnly()); err != ErrReduceOnly {
+		t.Fatal("the reduce-only order can not increase the position", err)
+	}
+
+	// the qty of the reduce-only order is up to the position
+	id, err := ob.ProcessLimitOrder(Sell, 105, 15, WithAccount("alice"), WithReduceOnly())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order Order
+	if ob.GetOrder(id, &order); order.Qty != 10 || order.OriginalQty != 10 {
+		t.Fatal("the reduce-only order should be reduced to 10", order)
+	}
+
+	// the position is reduced by the other order, so the resting reduce-only order only trades the rest of it
+	if _, err := ob.ProcessLimitOrder(Sell, 104, 6, WithAccount("alice")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 104, 6, WithAccount("carol")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 105, 10, WithAccount("carol")); err != nil {
+		t.Fatal(err)
+	}
+	status, err := ob.GetOrder(id, &order)
+	if err != nil || status != StatusCanceled || order.FilledQty != 4 {
+		t.Fatal("the reduce-only order should be canceled after 4 is filled", status, err, order)
+	}
+	if alice := ob.GetPosition("alice"); alice != 0 {
+		t.Fatal("the position of alice should be closed, but got", alice)
+	}
+	if bids := ob.GetBids(); len(bids) != 1 || bids[0].Qty != 6 {
+		t.Fatal("the rest of the order of carol should rest", bids)
+	}
+
+	// the taker reduce-only order stops trading when the position is closed
+	if _, err := ob.ProcessLimitOrder(Sell, 110, 20, WithAccount("dave")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 110, 20, WithAccount("bob"), WithReduceOnly()); err != nil {
+		t.Fatal(err)
+	}
+	if bob := ob.GetPosition("bob"); bob != 0 {
+		t.Fatal("the position of bob should be closed, but got", bob)
+	}
+	if asks := ob.GetAsks(); len(asks) != 1 || asks[0].Qty != 10 {
+		t.Fatal("the ask of dave should have 10 left", asks)
+	}
+
+	// the fill or kill order only counts the qty which the resting reduce-only order can still trade
+	ob, err = New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+	ob.ProcessLimitOrder(Sell, 100, 10, WithAccount("bob"))
+	ob.ProcessLimitOrder(Buy, 100, 10, WithAccount("alice"))
+	ob.ProcessLimitOrder(Sell, 101, 10, WithAccount("alice"), WithReduceOnly())
+	ob.ProcessLimitOrder(Sell, 100, 5, WithAccount("alice"))
+	ob.ProcessLimitOrder(Buy, 100, 5, WithAccount("carol"))
+	if _, err := ob.ProcessLimitOrder(Buy, 101, 10, WithAccount("carol"), WithTimeInForce(FOK)); err != ErrOrderNotFilled {
+		t.Fatal("the fill or kill order should be rejected", err)
+	}
+	if asks := ob.GetAsks(); len(asks) != 1 || asks[0].Qty != 10 {
+		t.Fatal("the reduce-only order of alice should rest", asks)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 101, 5, WithAccount("carol"), WithTimeInForce(FOK)); err != nil {
+		t.Fatal(err)
+	}
+	if alice := ob.GetPosition("alice"); alice != 0 {
+		t.Fatal("the position of alice should be closed, but got", alice)
+	}
+	t.Log("... Passed")
+}
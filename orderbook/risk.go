@@ -0,0 +1,354 @@
+package orderbook
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RiskReason is the typed reason of the rejection of a risk check
+type RiskReason string
+
+const (
+	RiskMaxQty        RiskReason = "max_qty"
+	RiskMaxNotional   RiskReason = "max_notional"
+	RiskPriceCollar   RiskReason = "price_collar"
+	RiskMaxOpenOrders RiskReason = "max_open_orders"
+	RiskRateLimit     RiskReason = "rate_limit"
+)
+
+// RiskError is the rejection of a risk check, Value is the value of the order which exceeds the Limit
+type RiskError struct {
+	Reason  RiskReason
+	Account string
+	Limit   int
+	Value   int
+}
+
+func (e *RiskError) Error() string {
+	return fmt.Sprintf("%s: %s of the account %q is %d, the limit is %d", ErrRiskRejected, e.Reason, e.Account, e.Value, e.Limit)
+}
+
+// Unwrap makes the rejection of the risk check match ErrRiskRejected
+func (e *RiskError) Unwrap() error {
+	return ErrRiskRejected
+}
+
+// RiskLimits are the limits of the risk checks, the limit is unlimited if it is 0
+type RiskLimits struct {
+	// MaxQty is the max. qty of an order in the smallest units
+	MaxQty int `json:"max_qty"`
+	// MaxNotional is the max. price * qty of an order in the smallest units, the market order is checked by its
+	// protection price or the best opposite price
+	MaxNotional int `json:"max_notional"`
+	// PriceCollar is the max. distance of the price of the limit order from the last trade price in basis points,
+	// the best opposite price is the reference before the first trade
+	PriceCollar int `json:"price_collar"`
+	// MaxOpenOrders is the max. number of the resting and untriggered stop orders of an account
+	MaxOpenOrders int `json:"max_open_orders"`
+	// MaxOrderRate is the max. number of the orders of an account per second
+	MaxOrderRate int `json:"max_order_rate"`
+}
+
+// validate checks if the limits are not negative
+func (l RiskLimits) validate() error {
+	if l.MaxQty < 0 || l.MaxNotional < 0 || l.PriceCollar < 0 || l.MaxOpenOrders < 0 || l.MaxOrderRate < 0 {
+		return ErrBadRiskLimits
+	}
+	return nil
+}
+
+// override returns the limits which are overridden by the non-zero limits of o
+func (l RiskLimits) override(o RiskLimits) RiskLimits {
+	for _, v := range []struct{ dst, src *int }{
+		{&l.MaxQty, &o.MaxQty},
+		{&l.MaxNotional, &o.MaxNotional},
+		{&l.PriceCollar, &o.PriceCollar},
+		{&l.MaxOpenOrders, &o.MaxOpenOrders},
+		{&l.MaxOrderRate, &o.MaxOrderRate},
+	} {
+		if *v.src != 0 {
+			*v.dst = *v.src
+		}
+	}
+	return l
+}
+
+// RiskConfig is the risk limits of the instrument of the orderbook and the limits of the accounts which override
+// the ones of the instrument
+type RiskConfig struct {
+	Limits   RiskLimits            `json:"limits"`
+	Accounts map[string]RiskLimits `json:"accounts"`
+}
+
+// Validate checks if the limits of the config are not negative
+func (c RiskConfig) Validate() error {
+	if err := c.Limits.validate(); err != nil {
+		return err
+	}
+	for account, l := range c.Accounts {
+		if err := l.validate(); err != nil {
+			return fmt.Errorf("%w: account %q", err, account)
+		}
+	}
+	return nil
+}
+
+// limits returns the limits of the account
+func (c RiskConfig) limits(account string) RiskLimits {
+	if l, exist := c.Accounts[account]; exist {
+		return c.Limits.override(l)
+	}
+	return c.Limits
+}
+
+// RiskState is the state of the orderbook when the order is checked
+type RiskState struct {
+	// Limits are the limits of the account of the order
+	Limits RiskLimits
+	// LastPrice is the last trade price, BestBid & BestAsk are the best prices of each side, 0 if there is none
+	LastPrice, BestBid, BestAsk int
+	// OpenOrders is the number of the resting and untriggered stop orders of the account of the order
+	OpenOrders int
+	// Now is the time of the order
+	Now time.Time
+}
+
+// RiskCheck checks the new order before it is processed, it returns a *RiskError if the order is rejected.
+// It is called by the matching loop of the orderbook.
+type RiskCheck interface {
+	Check(s RiskState, o *Order) error
+}
+
+// RiskRecorder is the RiskCheck which keeps the state of the accepted orders, Record is called with the order after
+// it passes all the checks of the chain
+type RiskRecorder interface {
+	RiskCheck
+	Record(s RiskState, o *Order)
+}
+
+// RiskCheckFunc is the function which is used as a RiskCheck
+type RiskCheckFunc func(s RiskState, o *Order) error
+
+// Check calls the function
+func (f RiskCheckFunc) Check(s RiskState, o *Order) error {
+	return f(s, o)
+}
+
+// DefaultRiskChecks returns the chain of the risk checks of the max. qty, max. notional, price collar, max. open
+// orders and the order rate limit
+func DefaultRiskChecks() []RiskCheck {
+	return []RiskCheck{
+		RiskCheckFunc(CheckMaxQty),
+		RiskCheckFunc(CheckMaxNotional),
+		RiskCheckFunc(CheckPriceCollar),
+		RiskCheckFunc(CheckMaxOpenOrders),
+		NewRateLimitCheck(),
+	}
+}
+
+// CheckMaxQty rejects the order which exceeds the max. qty
+func CheckMaxQty(s RiskState, o *Order) error {
+	if s.Limits.MaxQty > 0 && o.Qty > s.Limits.MaxQty {
+		return &RiskError{Reason: RiskMaxQty, Account: o.Account, Limit: s.Limits.MaxQty, Value: o.Qty}
+	}
+	return nil
+}
+
+// CheckMaxNotional rejects the order which exceeds the max. notional, the market order without the reference
+// price is not checked
+func CheckMaxNotional(s RiskState, o *Order) error {
+	if s.Limits.MaxNotional == 0 {
+		return nil
+	}
+	price := o.limitPrice()
+	if price == 0 {
+		price = s.BestAsk
+		if o.Side == Sell {
+			price = s.BestBid
+		}
+	}
+	if notional := price * o.Qty; notional > s.Limits.MaxNotional {
+		return &RiskError{Reason: RiskMaxNotional, Account: o.Account, Limit: s.Limits.MaxNotional, Value: notional}
+	}
+	return nil
+}
+
+// CheckPriceCollar rejects the limit order whose price is too far from the last trade price or the best opposite
+// price before the first trade, the value of the rejection is the distance in basis points. The market orders and
+// the untriggered stop orders are not checked.
+func CheckPriceCollar(s RiskState, o *Order) error {
+	if s.Limits.PriceCollar == 0 || o.PriceMode == Market || o.untriggered() {
+		return nil
+	}
+	ref := s.LastPrice
+	if ref == 0 {
+		ref = s.BestAsk
+		if o.Side == Sell {
+			ref = s.BestBid
+		}
+	}
+	if ref == 0 {
+		return nil
+	}
+	diff := o.Price - ref
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff*10000 > s.Limits.PriceCollar*ref {
+		return &RiskError{Reason: RiskPriceCollar, Account: o.Account, Limit: s.Limits.PriceCollar, Value: diff * 10000 / ref}
+	}
+	return nil
+}
+
+// CheckMaxOpenOrders rejects the order of the account which has the max. number of the open orders
+func CheckMaxOpenOrders(s RiskState, o *Order) error {
+	if s.Limits.MaxOpenOrders > 0 && s.OpenOrders >= s.Limits.MaxOpenOrders {
+		return &RiskError{Reason: RiskMaxOpenOrders, Account: o.Account, Limit: s.Limits.MaxOpenOrders, Value: s.OpenOrders + 1}
+	}
+	return nil
+}
+
+// rateLimitCheck limits the orders of each account in the sliding window of a second
+type rateLimitCheck struct {
+	sync.Mutex
+	// times are the times of the accepted orders of each account in the last second
+	times map[string][]time.Time
+}
+
+// NewRateLimitCheck returns the check of the max. order rate of each account, the orders without account are not
+// limited. It can be shared by the orderbooks to limit the orders of all of them.
+func NewRateLimitCheck() RiskCheck {
+	return &rateLimitCheck{times: make(map[string][]time.Time)}
+}
+
+// Check rejects the order if the account has the max. number of the orders in the last second
+func (r *rateLimitCheck) Check(s RiskState, o *Order) error {
+	if s.Limits.MaxOrderRate == 0 || len(o.Account) == 0 {
+		return nil
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	if times := r.window(s, o.Account); len(times) >= s.Limits.MaxOrderRate {
+		return &RiskError{Reason: RiskRateLimit, Account: o.Account, Limit: s.Limits.MaxOrderRate, Value: len(times) + 1}
+	}
+	return nil
+}
+
+// Record counts the order which passes all the checks in the rate of its account
+func (r *rateLimitCheck) Record(s RiskState, o *Order) {
+	if s.Limits.MaxOrderRate == 0 || len(o.Account) == 0 {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	r.times[o.Account] = append(r.window(s, o.Account), s.Now)
+}
+
+// window removes the times of the orders of the account before the last second and returns the rest of them,
+// it should be called with lock
+func (r *rateLimitCheck) window(s RiskState, account string) []time.Time {
+	times := r.times[account]
+	i := 0
+	for i < len(times) && !times[i].After(s.Now.Add(-time.Second)) {
+		i++
+	}
+	times = times[i:]
+	if len(times) == 0 {
+		delete(r.times, account)
+		return nil
+	}
+	r.times[account] = times
+	return times
+}
+
+// WithRiskChecks is an option for the chain of the risk checks, the orders are checked by the checks in order
+// before they are processed. The chain is DefaultRiskChecks if the option is not given.
+func WithRiskChecks(checks ...RiskCheck) Option {
+	return func(ob *OrderBook) error {
+		ob.riskChecks = append([]RiskCheck{}, checks...)
+		return nil
+	}
+}
+
+// WithRiskConfig is an option for the risk limits of the orderbook
+func WithRiskConfig(cfg RiskConfig) Option {
+	return func(ob *OrderBook) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		ob.riskConfig = cfg
+		return nil
+	}
+}
+
+// SetRiskConfig reloads the risk limits of the orderbook, the new limits are applied to the orders after it
+func (ob *OrderBook) SetRiskConfig(cfg RiskConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	return ob.exec(func() error {
+		ob.riskConfig = cfg
+		return nil
+	})
+}
+
+// RiskConfig returns the risk limits of the orderbook
+func (ob *OrderBook) RiskConfig() RiskConfig {
+	ob.RLock()
+	defer ob.RUnlock()
+	return ob.riskConfig
+}
+
+// checkRisk runs the order through the chain of the risk checks, it should be called with lock
+func (ob *OrderBook) checkRisk(o *Order) error {
+	if len(ob.riskChecks) == 0 {
+		return nil
+	}
+	return ob.runRiskChecks(ob.riskState(o), o)
+}
+
+// riskState returns the state of the orderbook for the risk checks of the order, it should be called with lock
+func (ob *OrderBook) riskState(o *Order) RiskState {
+	s := RiskState{
+		Limits:     ob.riskConfig.limits(o.Account),
+		LastPrice:  ob.lastPrice,
+		OpenOrders: ob.openOrders(o.Account),
+		Now:        o.Time,
+	}
+	if best := ob.bids.best(); best != nil {
+		s.BestBid = best.Price
+	}
+	if best := ob.asks.best(); best != nil {
+		s.BestAsk = best.Price
+	}
+	return s
+}
+
+// runRiskChecks runs the order through the chain of the risk checks with the state, the order is recorded by the
+// checks only if it passes all of them
+func (ob *OrderBook) runRiskChecks(s RiskState, o *Order) error {
+	for _, check := range ob.riskChecks {
+		if err := check.Check(s, o); err != nil {
+			return err
+		}
+	}
+	for _, check := range ob.riskChecks {
+		if r, ok := check.(RiskRecorder); ok {
+			r.Record(s, o)
+		}
+	}
+	return nil
+}
+
+// openOrders returns the number of the resting and untriggered stop orders of the account, it should be called
+// with lock
+func (ob *OrderBook) openOrders(account string) int {
+	if len(account) == 0 {
+		return 0
+	}
+	return ob.bids.accounts[account] + ob.asks.accounts[account] +
+		ob.stops.buys.accounts[account] + ob.stops.sells.accounts[account]
+}
@@ -0,0 +1,172 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRiskChecks(t *testing.T) {
+
+	t.Log("start testing the risk checks...")
+
+	if _, err := New(WithRiskConfig(RiskConfig{Limits: RiskLimits{MaxQty: -1}})); err != ErrBadRiskLimits {
+		t.Fatal("wrong error type", err)
+	}
+
+	clock := newTestClock()
+	ob, err := New(WithClock(clock.Now), WithRiskConfig(RiskConfig{
+		Limits: RiskLimits{MaxQty: 10, MaxNotional: 2000, PriceCollar: 500, MaxOpenOrders: 3, MaxOrderRate: 5},
+		// bob can trade larger orders but only one order per second
+		Accounts: map[string]RiskLimits{"bob": {MaxQty: 100, MaxNotional: 100000, MaxOrderRate: 1}},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	reason := func(_ string, err error) RiskReason {
+		t.Helper()
+		var re *RiskError
+		if !errors.Is(err, ErrRiskRejected) || !errors.As(err, &re) {
+			t.Fatal("the order should be rejected by the risk check, but got", err)
+		}
+		return re.Reason
+	}
+
+	if r := reason(ob.ProcessLimitOrder(Buy, 100, 11, WithAccount("alice"))); r != RiskMaxQty {
+		t.Fatal("wrong reason", r)
+	}
+	if r := reason(ob.ProcessLimitOrder(Buy, 201, 10, WithAccount("alice"))); r != RiskMaxNotional {
+		t.Fatal("wrong reason", r)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 50, 50, WithAccount("bob")); err != nil {
+		t.Fatal("the limits of bob should override the ones of the instrument", err)
+	}
+	if r := reason(ob.ProcessLimitOrder(Buy, 50, 50, WithAccount("bob"))); r != RiskRateLimit {
+		t.Fatal("wrong reason", r)
+	}
+	clock.Add(time.Second)
+	if _, err := ob.ProcessLimitOrder(Sell, 52, 1, WithAccount("bob")); err != nil {
+		t.Fatal("the rate limit of bob should be reset after a second", err)
+	}
+
+	// the price collar is 5% of the best opposite price 52 before the first trade
+	if r := reason(ob.ProcessLimitOrder(Buy, 49, 1, WithAccount("alice"))); r != RiskPriceCollar {
+		t.Fatal("wrong reason", r)
+	}
+	var id string
+	for i := 0; i < 3; i++ {
+		if id, err = ob.ProcessLimitOrder(Buy, 50, 1, WithAccount("alice")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if r := reason(ob.ProcessLimitOrder(Buy, 50, 1, WithAccount("alice"), WithStopPrice(55))); r != RiskMaxOpenOrders {
+		t.Fatal("wrong reason", r)
+	}
+
+	// the amended order is checked as well, but it is not counted as another open order
+	if r := reason("", ob.AmendOrder(id, 50, 11)); r != RiskMaxQty {
+		t.Fatal("wrong reason", r)
+	}
+	if r := reason("", ob.AmendOrder(id, 40, 1)); r != RiskPriceCollar {
+		t.Fatal("wrong reason", r)
+	}
+	if err := ob.AmendOrder(id, 51, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// the limits are reloaded at runtime
+	if err := ob.SetRiskConfig(RiskConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 10, 1000, WithAccount("alice")); err != nil {
+		t.Fatal("the orders should not be limited after the reload", err)
+	}
+	if cfg := ob.RiskConfig(); cfg.Limits != (RiskLimits{}) {
+		t.Fatal("the limits should be reloaded", cfg)
+	}
+	t.Log("... Passed")
+}
+
+func TestRiskTriggeredStop(t *testing.T) {
+
+	t.Log("start testing the price collar of the triggered stop orders...")
+
+	ob, err := New(WithRiskConfig(RiskConfig{Limits: RiskLimits{PriceCollar: 500}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	ob.ProcessLimitOrder(Sell, 100, 1, WithAccount("bob"))
+	ob.ProcessLimitOrder(Buy, 100, 1, WithAccount("alice"))
+	// the stop orders are checked by the last price 104 which triggers them, not the last price 100 when they are
+	// submitted
+	far, err := ob.ProcessLimitOrder(Buy, 98, 1, WithAccount("carol"), WithStopPrice(104))
+	if err != nil {
+		t.Fatal(err)
+	}
+	near, err := ob.ProcessLimitOrder(Buy, 106, 1, WithAccount("carol"), WithStopPrice(104))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ob.ProcessLimitOrder(Sell, 104, 1, WithAccount("bob"))
+	ob.ProcessLimitOrder(Buy, 104, 1, WithAccount("alice"))
+	var order Order
+	if status, _ := ob.GetOrder(far, &order); status != StatusCanceled {
+		t.Fatal("the stop order out of the collar should be rejected", status)
+	}
+	if status, _ := ob.GetOrder(near, &order); status != StatusPending {
+		t.Fatal("the stop order in the collar should rest", status)
+	}
+	t.Log("... Passed")
+}
+
+func TestCustomRiskChecks(t *testing.T) {
+
+	t.Log("start testing the custom chain of the risk checks...")
+
+	blocked := RiskCheckFunc(func(s RiskState, o *Order) error {
+		if o.Account == "mallory" {
+			return &RiskError{Reason: "blocked", Account: o.Account}
+		}
+		return nil
+	})
+	ob, err := New(WithRiskChecks(blocked), WithRiskConfig(RiskConfig{Limits: RiskLimits{MaxQty: 1}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 1, WithAccount("mallory")); !errors.Is(err, ErrRiskRejected) {
+		t.Fatal("the order should be rejected by the custom check", err)
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 5, WithAccount("alice")); err != nil {
+		t.Fatal("the default checks should be replaced", err)
+	}
+
+	// the order which is rejected by a later check of the chain is not counted by the rate limit
+	clock := newTestClock()
+	ob, err = New(WithClock(clock.Now), WithRiskChecks(NewRateLimitCheck(), RiskCheckFunc(CheckMaxQty)),
+		WithRiskConfig(RiskConfig{Limits: RiskLimits{MaxQty: 1, MaxOrderRate: 2}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+	for i := 0; i < 3; i++ {
+		if _, err := ob.ProcessLimitOrder(Buy, 100, 5, WithAccount("alice")); !errors.Is(err, ErrRiskRejected) {
+			t.Fatal("the order should be rejected by the max. qty", err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := ob.ProcessLimitOrder(Buy, 100, 1, WithAccount("alice")); err != nil {
+			t.Fatal("the rejected orders should not be counted", err)
+		}
+	}
+	var re *RiskError
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 1, WithAccount("alice")); !errors.As(err, &re) || re.Reason != RiskRateLimit {
+		t.Fatal("the order should be rejected by the rate limit", err)
+	}
+	t.Log("... Passed")
+}
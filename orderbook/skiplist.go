@@ -0,0 +1,114 @@
+package orderbook
+
+import "math/rand"
+
+// maxSkiplistLevel is the max level of the skiplist, it is enough for 2^16 price levels
+const maxSkiplistLevel = 16
+
+// skipNode is the node of the skiplist
+type skipNode struct {
+	price int
+	level *priceLevel
+	next  []*skipNode
+}
+
+// skiplist is the sorted price levels, the first node has the highest priority
+type skiplist struct {
+	// less reports whether the price a has higher priority than b
+	less   func(a, b int) bool
+	head   *skipNode
+	height int
+	length int
+	// rand is used by the level of the new node, the seed is fixed so the structure is the same
+	// for the same inputs
+	rand *rand.Rand
+}
+
+// newSkiplist returns an empty skiplist which is sorted by less
+func newSkiplist(less func(a, b int) bool) *skiplist {
+	return &skiplist{
+		less:   less,
+		head:   &skipNode{next: make([]*skipNode, maxSkiplistLevel)},
+		height: 1,
+		rand:   rand.New(rand.NewSource(1)),
+	}
+}
+
+// Len returns the number of price levels
+func (s *skiplist) Len() int { return s.length }
+
+// first returns the node with the highest priority, nil if the skiplist is empty
+func (s *skiplist) first() *skipNode {
+	return s.head.next[0]
+}
+
+// randomHeight returns the height of the new node
+func (s *skiplist) randomHeight() int {
+	h := 1
+	for h < maxSkiplistLevel && s.rand.Intn(4) == 0 {
+		h++
+	}
+	return h
+}
+
+// search returns the last nodes whose price is prior to the price at each level
+func (s *skiplist) search(price int) []*skipNode {
+	prev := make([]*skipNode, maxSkiplistLevel)
+	x := s.head
+	for i := s.height - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.less(x.next[i].price, price) {
+			x = x.next[i]
+		}
+		prev[i] = x
+	}
+	return prev
+}
+
+// get returns the price level of the price, nil if it does not exist
+func (s *skiplist) get(price int) *priceLevel {
+	x := s.head
+	for i := s.height - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.less(x.next[i].price, price) {
+			x = x.next[i]
+		}
+	}
+	if x = x.next[0]; x != nil && x.price == price {
+		return x.level
+	}
+	return nil
+}
+
+// insert inserts the price level, the price of the level should not exist in the skiplist
+func (s *skiplist) insert(level *priceLevel) {
+	prev := s.search(level.price)
+	h := s.randomHeight()
+	if h > s.height {
+		for i := s.height; i < h; i++ {
+			prev[i] = s.head
+		}
+		s.height = h
+	}
+
+	node := &skipNode{price: level.price, level: level, next: make([]*skipNode, h)}
+	for i := 0; i < h; i++ {
+		node.next[i] = prev[i].next[i]
+		prev[i].next[i] = node
+	}
+	s.length++
+}
+
+// remove removes the price level of the price
+func (s *skiplist) remove(price int) {
+	prev := s.search(price)
+	node := prev[0].next[0]
+	if node == nil || node.price != price {
+		return
+	}
+	for i := 0; i < len(node.next); i++ {
+		prev[i].next[i] = node.next[i]
+	}
+	for s.height > 1 && s.head.next[s.height-1] == nil {
+		s.height--
+	}
+	s.length--
+}
@@ -0,0 +1,295 @@
+package orderbook
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// snapshotMagic is the beginning of the snapshot file
+var snapshotMagic = [4]byte{'M', 'T', 'O', 'B'}
+
+// snapshotVersion is the version of the format of the snapshot, it should be increased when the format is changed
+const snapshotVersion uint16 = 2
+
+// snapshotState is the state of the orderbook in the snapshot, the snapshot is the magic, the version (big endian)
+// and the state encoded by gob
+type snapshotState struct {
+	// Seq is the sequence number of the market data, ExecSeq is the sequence number of the execution reports and
+	// JournalSeq is the sequence number of the last command of the journal which is included in the snapshot
+	Seq        uint64
+	ExecSeq    uint64
+	JournalSeq uint64
+	// Bids & Asks are the resting orders by priority, Stops are the untriggered stop orders by priority
+	Bids  []snapshotOrder
+	Asks  []snapshotOrder
+	Stops []snapshotOrder
+	// LastPrice is the last trade price which triggers the stop orders
+	LastPrice int
+	// Positions are the net positions of the accounts
+	Positions map[string]int
+	Done      map[string]Order
+	Canceled  map[string]Order
+	Fills     []Fill
+	// Balances are the balances of the accounts by account and Holds are the funds held by the orders by order id,
+	// they are only in the snapshot of the ledger
+	Balances map[string][]Balance
+	Holds    map[string]snapshotHold
+}
+
+// snapshotOrder is the resting order with its unexported states
+type snapshotOrder struct {
+	Order    Order
+	Notional int
+}
+
+// snapshotHold is the funds which are held by an order
+type snapshotHold struct {
+	Account string
+	Asset   string
+	Amount  int
+}
+
+// WithSnapshot is an option for the path of the snapshot of the orderbook and the interval of taking snapshots,
+// the snapshot is only taken by SaveSnapshot if the interval is 0
+func WithSnapshot(path string, interval time.Duration) Option {
+	return func(ob *OrderBook) error {
+		if len(path) == 0 {
+			return errors.New("the path of the snapshot is empty")
+		}
+		if interval < 0 {
+			return ErrBadSnapshotInterval
+		}
+		ob.snapshotPath = path
+		ob.snapshotInterval = interval
+		return nil
+	}
+}
+
+// Snapshot writes the snapshot of the orderbook to w, the matching loop holds the lock when it changes the orderbook
+func (ob *OrderBook) Snapshot(w io.Writer) error {
+	ob.RLock()
+	defer ob.RUnlock()
+	return ob.snapshot(w)
+}
+
+// snapshot writes the snapshot of the orderbook to w without lock
+func (ob *OrderBook) snapshot(w io.Writer) error {
+	state := snapshotState{
+		Seq:        ob.seq,
+		ExecSeq:    ob.execSeq,
+		JournalSeq: ob.journalSeq,
+		Bids:       snapshotOrders(ob.bids.orders()),
+		Asks:       snapshotOrders(ob.asks.orders()),
+		Stops:      snapshotOrders(ob.stops.orders()),
+		LastPrice:  ob.lastPrice,
+		Positions:  ob.positions,
+		Done:       ob.Done,
+		Canceled:   ob.Canceled,
+		Fills:      ob.Fills,
+	}
+	return writeSnapshot(w, &state)
+}
+
+// writeSnapshot writes the magic, the version and the state to w
+func writeSnapshot(w io.Writer, state *snapshotState) error {
+	if _, err := w.Write(snapshotMagic[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, snapshotVersion); err != nil {
+		return err
+	}
+	return gob.NewEncoder(w).Encode(state)
+}
+
+// readSnapshot reads the state from r and checks its magic and version
+func readSnapshot(r io.Reader) (*snapshotState, error) {
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil || magic != snapshotMagic {
+		return nil, ErrBadSnapshot
+	}
+	var version uint16
+	if err := binary.Read(r, binary.BigEndian, &version); err != nil {
+		return nil, ErrBadSnapshot
+	}
+	if version != snapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrBadSnapshotVersion, version)
+	}
+
+	var state snapshotState
+	if err := gob.NewDecoder(r).Decode(&state); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadSnapshot, err)
+	}
+	return &state, nil
+}
+
+// snapshotOrders returns the orders with their unexported states
+func snapshotOrders(orders []*Order) []snapshotOrder {
+	sos := make([]snapshotOrder, len(orders))
+	for i, o := range orders {
+		sos[i] = snapshotOrder{Order: *o, Notional: o.notional}
+	}
+	return sos
+}
+
+// Restore replaces the state of the orderbook by the snapshot from r
+func (ob *OrderBook) Restore(r io.Reader) error {
+	state, err := readSnapshot(r)
+	if err != nil {
+		return err
+	}
+
+	return ob.exec(func() error {
+		ob.restore(state)
+		return nil
+	})
+}
+
+// restore replaces the state of the orderbook by the state of the snapshot without lock
+func (ob *OrderBook) restore(state *snapshotState) {
+	ob.seq, ob.execSeq, ob.journalSeq = state.Seq, state.ExecSeq, state.JournalSeq
+	ob.bids, ob.asks = newBookSide(Buy), newBookSide(Sell)
+	for _, q := range []struct {
+		side   *bookSide
+		orders []snapshotOrder
+	}{{ob.bids, state.Bids}, {ob.asks, state.Asks}} {
+		for _, so := range q.orders {
+			o := so.Order
+			o.notional = so.Notional
+			q.side.push(&o)
+		}
+	}
+	ob.stops = newTriggerBook()
+	for _, so := range state.Stops {
+		o := so.Order
+		ob.stops.own(o.Side).push(&o)
+	}
+	ob.lastPrice = state.LastPrice
+	ob.positions = state.Positions
+	if ob.positions == nil {
+		ob.positions = make(map[string]int)
+	}
+
+	ob.Done, ob.Canceled, ob.Fills = state.Done, state.Canceled, state.Fills
+	if ob.Done == nil {
+		ob.Done = make(map[string]Order)
+	}
+	if ob.Canceled == nil {
+		ob.Canceled = make(map[string]Order)
+	}
+	if ob.Fills == nil {
+		ob.Fills = make([]Fill, 0)
+	}
+	ob.trades = ob.trades[:0]
+	ob.reindex = true
+}
+
+// SaveSnapshot writes the snapshot to the path of the snapshot of the orderbook, and the journal is truncated
+// because all of its commands are included in the snapshot
+func (ob *OrderBook) SaveSnapshot() error {
+	if len(ob.snapshotPath) == 0 {
+		return ErrSnapshotDisabled
+	}
+
+	// the commands are journaled by the matching loop, so the snapshot and the journal are consistent
+	return ob.exec(ob.saveSnapshot)
+}
+
+// saveSnapshot writes the snapshot and truncates the journal without lock
+func (ob *OrderBook) saveSnapshot() error {
+	if err := saveSnapshotFile(ob.snapshotPath, ob.snapshot); err != nil {
+		return err
+	}
+
+	// the commands which are included in the snapshot are skipped by Replay if it fails to truncate the journal
+	if ob.journal != nil {
+		return ob.journal.truncate()
+	}
+	return nil
+}
+
+// saveSnapshotFile writes the snapshot to the path by write, the snapshot is replaced atomically and the old one is
+// kept if it fails to write the new one
+func saveSnapshotFile(path string, write func(w io.Writer) error) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	if err := write(w); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	if dir, err := os.Open(filepath.Dir(path)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	return nil
+}
+
+// LoadSnapshot restores the orderbook by the snapshot at the path of the snapshot of the orderbook,
+// it does nothing if the snapshot does not exist. It should be called before Replay.
+func (ob *OrderBook) LoadSnapshot() error {
+	if len(ob.snapshotPath) == 0 {
+		return ErrSnapshotDisabled
+	}
+	return loadSnapshotFile(ob.snapshotPath, ob.Restore)
+}
+
+// loadSnapshotFile restores the snapshot at the path by restore, it does nothing if the snapshot does not exist
+func loadSnapshotFile(path string, restore func(r io.Reader) error) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return restore(bufio.NewReader(f))
+}
+
+// AutoSnapshot is the routine for taking the snapshots periodically, it returns immediately if the interval
+// of the snapshot is 0
+func (ob *OrderBook) AutoSnapshot(ctx context.Context) {
+	if ob.snapshotInterval == 0 {
+		return
+	}
+	log.Printf("take snapshot to %s every %s\n", ob.snapshotPath, ob.snapshotInterval)
+	ticker := time.NewTicker(ob.snapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := ob.SaveSnapshot(); err != nil {
+				log.Println("orderbook: failed to take the snapshot:", err)
+			}
+		case <-ctx.Done():
+			log.Println("orderbook: auto snapshot is leaving...")
+			return
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package orderbook
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// sameBook checks if the resting orders and the history of the orderbooks are the same
+func sameBook(t *testing.T, want, got *OrderBook) {
+	t.Helper()
+	for _, side := range []Side{Buy, Sell} {
+		wos, gos := want.own(side).orders(), got.own(side).orders()
+		if len(wos) != len(gos) {
+			t.Fatalf("[%s] the number of the orders should be %d, but got %d", side, len(wos), len(gos))
+		}
+		for i := range wos {
+			w, g := wos[i], gos[i]
+			if w.ID != g.ID || w.Price != g.Price || w.Qty != g.Qty || w.FilledQty != g.FilledQty ||
+				w.AvgPrice != g.AvgPrice || w.notional != g.notional || !w.Time.Equal(g.Time) {
+				t.Fatalf("[%s] the order[%d] should be %s, but got %s", side, i, w, g)
+			}
+		}
+	}
+	if ws, gs := want.stops.orders(), got.stops.orders(); len(ws) != len(gs) || want.lastPrice != got.lastPrice {
+		t.Fatalf("the stop orders are not restored, stops: %d/%d, last price: %d/%d", len(gs), len(ws), got.lastPrice, want.lastPrice)
+	}
+	if len(want.Done) != len(got.Done) || len(want.Canceled) != len(got.Canceled) || len(want.Fills) != len(got.Fills) {
+		t.Fatalf("the history is not restored, done: %d/%d, canceled: %d/%d, fills: %d/%d",
+			len(got.Done), len(want.Done), len(got.Canceled), len(want.Canceled), len(got.Fills), len(want.Fills))
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+
+	t.Log("start testing the snapshot of the orderbook...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := ob.ProcessLimitOrder(Buy, 100-i, 10); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ob.ProcessLimitOrder(Sell, 101+i, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := ob.ProcessLimitOrder(Sell, 99, 15); err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.CancelOrder(ob.GetAsks()[2].ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.ProcessMarketOrder(Sell, 5, WithStopPrice(90)); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := ob.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+
+	restored, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := restored.Restore(bytes.NewReader(b)); err != nil {
+		t.Fatal(err)
+	}
+	sameBook(t, ob, restored)
+
+	// the partially filled order keeps its average price after more fills
+	if _, err := ob.ProcessLimitOrder(Sell, 99, 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := restored.ProcessLimitOrder(Sell, 99, 10); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := ob.GetBids()[0], restored.GetBids()[0]; want.ID != got.ID || want.AvgPrice != got.AvgPrice {
+		t.Fatalf("the bid should be %s, but got %s", want, got)
+	}
+
+	// the unknown format and version are rejected
+	if err := restored.Restore(bytes.NewReader([]byte("bad snapshot"))); !errors.Is(err, ErrBadSnapshot) {
+		t.Fatal("the bad snapshot should be rejected, but got", err)
+	}
+	b[5]++
+	if err := restored.Restore(bytes.NewReader(b)); !errors.Is(err, ErrBadSnapshotVersion) {
+		t.Fatal("the unknown version should be rejected, but got", err)
+	}
+}
+
+func TestSnapshotJournalTruncation(t *testing.T) {
+
+	t.Log("start testing the journal truncation by the snapshot...")
+
+	dir := t.TempDir()
+	journalPath := filepath.Join(dir, "default.journal")
+	snapshotPath := filepath.Join(dir, "default.snapshot")
+
+	j, err := OpenJournal(journalPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ob, err := New(WithJournal(j), WithSnapshot(snapshotPath, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := ob.ProcessLimitOrder(Buy, 100-i, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	beforeSnapshot, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ob.SaveSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(journalPath); err != nil || info.Size() != 0 {
+		t.Fatal("the journal should be truncated by the snapshot", err)
+	}
+
+	// the commands after the snapshot
+	if _, err := ob.ProcessLimitOrder(Sell, 99, 15); err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.CancelOrder(ob.GetBids()[1].ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	j.Close()
+
+	restore := func() *OrderBook {
+		j, err := OpenJournal(journalPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { j.Close() })
+		restored, err := New(WithJournal(j), WithSnapshot(snapshotPath, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := restored.LoadSnapshot(); err != nil {
+			t.Fatal(err)
+		}
+		if err := restored.Replay(); err != nil {
+			t.Fatal(err)
+		}
+		return restored
+	}
+	sameBook(t, ob, restore())
+
+	// the commands in the snapshot are skipped if the journal was not truncated by a crash
+	afterSnapshot, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(journalPath, append(beforeSnapshot, afterSnapshot...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sameBook(t, ob, restore())
+}
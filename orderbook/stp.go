@@ -0,0 +1,133 @@
+package orderbook
+
+import "fmt"
+
+// STP is the mode of the self-trade prevention which is applied when the taker order would trade with the resting
+// order of the same account
+type STP int
+
+const (
+	// STPNone allows the self-trade
+	STPNone STP = iota
+	// STPCancelNewest cancels the rest of the taker order
+	STPCancelNewest
+	// STPCancelOldest cancels the resting order and the taker order keeps trading
+	STPCancelOldest
+	// STPCancelBoth cancels the resting order and the rest of the taker order
+	STPCancelBoth
+	// STPDecrementCancel decrements both orders by the smaller qty and cancels the smaller one, both of them are
+	// canceled if they have the same qty
+	STPDecrementCancel
+)
+
+func (s STP) String() string {
+	if s < STPNone || s > STPDecrementCancel {
+		return "unknown"
+	}
+	return [...]string{
+		"none",
+		"cancel_newest",
+		"cancel_oldest",
+		"cancel_both",
+		"decrement_cancel",
+	}[s]
+}
+
+// ParseSTP returns the mode of the self-trade prevention by its name
+func ParseSTP(name string) (STP, error) {
+	for s := STPNone; s <= STPDecrementCancel; s++ {
+		if s.String() == name {
+			return s, nil
+		}
+	}
+	return STPNone, ErrBadSTP
+}
+
+// WithSelfTradePrevention is an option for the default mode of the self-trade prevention of the orderbook,
+// it is applied to the orders without their own mode
+func WithSelfTradePrevention(mode STP) Option {
+	return func(ob *OrderBook) error {
+		if mode < STPNone || mode > STPDecrementCancel {
+			return ErrBadSTP
+		}
+		ob.stp = mode
+		return nil
+	}
+}
+
+// WithSTP is an option for the mode of the self-trade prevention of the order, the mode of the taker order is
+// applied when it would trade with the resting order of the same account
+func WithSTP(mode STP) OrderOption {
+	return func(o *Order) error {
+		if mode < STPNone || mode > STPDecrementCancel {
+			return ErrBadSTP
+		}
+		o.STP = mode
+		return nil
+	}
+}
+
+// stpMode returns the mode of the self-trade prevention of the taker order
+func (ob *OrderBook) stpMode(taker *Order) STP {
+	if taker.STP != STPNone {
+		return taker.STP
+	}
+	return ob.stp
+}
+
+// selfTrade checks if the taker order would trade with the maker order of the same account
+func selfTrade(maker, taker *Order) bool {
+	return len(taker.Account) > 0 && maker.Account == taker.Account
+}
+
+// preventSelfTrade applies the mode to the maker and the taker order of the same account instead of trading them,
+// it returns true if the rest of the taker order is canceled. It should be called with lock.
+func (ob *OrderBook) preventSelfTrade(q *bookSide, maker, taker *Order, mode STP) bool {
+	reason := fmt.Sprintf("%s: %s", ErrSelfTrade, mode)
+	switch mode {
+	case STPCancelNewest:
+		taker.selfTraded = true
+	case STPCancelOldest:
+		ob.cancelSelfTrade(q, maker, reason)
+	case STPCancelBoth:
+		ob.cancelSelfTrade(q, maker, reason)
+		taker.selfTraded = true
+	case STPDecrementCancel:
+		makerQty := maker.Qty + maker.HiddenQty
+		if makerQty <= taker.Qty {
+			ob.cancelSelfTrade(q, maker, reason)
+			taker.decrement(makerQty)
+			taker.selfTraded = taker.Qty == 0
+			break
+		}
+
+		// the hidden qty of the iceberg order is decremented first
+		qty := taker.Qty
+		hidden := maker.HiddenQty
+		if hidden > qty {
+			hidden = qty
+		}
+		maker.HiddenQty -= hidden
+		q.resize(maker, maker.Qty-(qty-hidden))
+		maker.OriginalQty -= qty
+		ob.release(maker, false)
+		ob.reportReason(ExecReplaced, maker, restingStatus(maker), reason)
+		taker.decrement(qty)
+		taker.selfTraded = true
+	}
+	return taker.selfTraded
+}
+
+// cancelSelfTrade cancels the resting order by the self-trade prevention and reports the reason
+func (ob *OrderBook) cancelSelfTrade(q *bookSide, o *Order, reason string) {
+	q.remove(o)
+	ob.release(o, true)
+	ob.Canceled[o.ID.String()] = *o
+	ob.reportReason(ExecCanceled, o, StatusCanceled, reason)
+}
+
+// decrement cancels the qty of the order without filling it
+func (o *Order) decrement(qty int) {
+	o.Qty -= qty
+	o.OriginalQty -= qty
+}
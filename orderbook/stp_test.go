@@ -0,0 +1,175 @@
+package orderbook
+
+import (
+	"testing"
+)
+
+func TestSelfTradePrevention(t *testing.T) {
+
+	t.Log("start testing the self-trade prevention...")
+
+	if _, err := New(WithSelfTradePrevention(STP(9))); err != ErrBadSTP {
+		t.Fatal("wrong error type", err)
+	}
+
+	reasons := func(ob *OrderBook, ch <-chan ExecutionReport) map[string]string {
+		ob.UnsubscribeExecutions(ch)
+		m := make(map[string]string)
+		for r := range ch {
+			if r.Reason != "" {
+				m[r.Order.ID.String()] = r.Type.String() + "/" + r.Reason
+			}
+		}
+		return m
+	}
+
+	t.Run("cancel newest", func(t *testing.T) {
+		ob, err := New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ob.Close()
+		ch := ob.SubscribeExecutions(ExecutionFilter{Account: "alice"}, 64)
+
+		if _, err := ob.ProcessLimitOrder(Buy, 100, 10, WithSTP(STP(9))); err != ErrBadSTP {
+			t.Fatal("wrong error type", err)
+		}
+		maker, _ := ob.ProcessLimitOrder(Sell, 100, 10, WithAccount("alice"))
+		taker, err := ob.ProcessLimitOrder(Buy, 100, 5, WithAccount("alice"), WithSTP(STPCancelNewest))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var order Order
+		if status, _ := ob.GetOrder(taker, &order); status != StatusCanceled || order.Qty != 5 {
+			t.Fatal("the taker order should be canceled", status, order)
+		}
+		if status, _ := ob.GetOrder(maker, &order); status != StatusPending || order.Qty != 10 {
+			t.Fatal("the maker order should rest", status, order)
+		}
+		if got := reasons(ob, ch); len(got) != 1 || got[taker] != "canceled/self-trade prevented: cancel_newest" {
+			t.Fatal("wrong reports", got)
+		}
+	})
+
+	t.Run("cancel oldest", func(t *testing.T) {
+		ob, err := New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ob.Close()
+		ch := ob.SubscribeExecutions(ExecutionFilter{Account: "alice"}, 64)
+
+		maker, _ := ob.ProcessLimitOrder(Sell, 100, 10, WithAccount("alice"))
+		ob.ProcessLimitOrder(Sell, 101, 10, WithAccount("bob"))
+		if _, err := ob.ProcessLimitOrder(Buy, 101, 15, WithAccount("alice"), WithSTP(STPCancelOldest)); err != nil {
+			t.Fatal(err)
+		}
+		var order Order
+		if status, _ := ob.GetOrder(maker, &order); status != StatusCanceled {
+			t.Fatal("the maker order should be canceled", status)
+		}
+		if len(ob.GetTrades()) != 1 || ob.GetPosition("alice") != 10 {
+			t.Fatal("the taker order should trade with bob", ob.GetTrades())
+		}
+		if bids := ob.GetBids(); len(bids) != 1 || bids[0].Qty != 5 {
+			t.Fatal("the rest of the taker order should rest", bids)
+		}
+		if got := reasons(ob, ch); len(got) != 1 || got[maker] != "canceled/self-trade prevented: cancel_oldest" {
+			t.Fatal("wrong reports", got)
+		}
+	})
+
+	t.Run("cancel both", func(t *testing.T) {
+		ob, err := New(WithSelfTradePrevention(STPCancelBoth))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ob.Close()
+
+		// the orders without account are never prevented
+		ob.ProcessLimitOrder(Sell, 100, 10)
+		ob.ProcessLimitOrder(Buy, 100, 5)
+		if len(ob.GetTrades()) != 1 {
+			t.Fatal("the orders without account should trade")
+		}
+
+		maker, _ := ob.ProcessLimitOrder(Sell, 99, 10, WithAccount("alice"))
+		taker, _ := ob.ProcessMarketOrder(Buy, 10, WithAccount("alice"))
+		var order Order
+		if status, _ := ob.GetOrder(maker, &order); status != StatusCanceled {
+			t.Fatal("the maker order should be canceled", status)
+		}
+		if status, _ := ob.GetOrder(taker, &order); status != StatusCanceled {
+			t.Fatal("the taker order should be canceled", status)
+		}
+		if asks := ob.GetAsks(); len(asks) != 1 || asks[0].Price != 100 || asks[0].Qty != 5 {
+			t.Fatal("the other ask should not be traded", asks)
+		}
+	})
+
+	t.Run("fill or kill", func(t *testing.T) {
+		ob, err := New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ob.Close()
+
+		// the maker order of the same account is canceled instead of filling the taker order
+		maker, _ := ob.ProcessLimitOrder(Sell, 100, 10, WithAccount("alice"))
+		if _, err := ob.ProcessLimitOrder(Buy, 100, 10, WithAccount("alice"), WithSTP(STPCancelOldest), WithTimeInForce(FOK)); err != ErrOrderNotFilled {
+			t.Fatal("the fill or kill order should be rejected", err)
+		}
+		var order Order
+		if status, _ := ob.GetOrder(maker, &order); status != StatusPending || len(ob.GetBids()) != 0 {
+			t.Fatal("the book should not be changed", status, ob.GetBids())
+		}
+
+		ob.ProcessLimitOrder(Sell, 101, 10, WithAccount("bob"))
+		if _, err := ob.ProcessLimitOrder(Buy, 101, 10, WithAccount("alice"), WithSTP(STPCancelOldest), WithTimeInForce(FOK)); err != nil {
+			t.Fatal(err)
+		}
+		if status, _ := ob.GetOrder(maker, &order); status != StatusCanceled {
+			t.Fatal("the maker order should be canceled", status)
+		}
+		if ob.GetPosition("alice") != 10 || len(ob.GetBids()) != 0 || len(ob.GetAsks()) != 0 {
+			t.Fatal("the taker order should be filled by bob", ob.GetBids(), ob.GetAsks())
+		}
+	})
+
+	t.Run("decrement and cancel", func(t *testing.T) {
+		ob, err := New(WithSelfTradePrevention(STPDecrementCancel))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ob.Close()
+		ch := ob.SubscribeExecutions(ExecutionFilter{Account: "alice"}, 64)
+
+		// the smaller taker order is canceled and the maker order is decremented
+		maker, _ := ob.ProcessLimitOrder(Sell, 100, 10, WithAccount("alice"), WithDisplayQty(4))
+		small, _ := ob.ProcessLimitOrder(Buy, 100, 3, WithAccount("alice"))
+		var order Order
+		if status, _ := ob.GetOrder(maker, &order); status != StatusPending || order.Qty != 4 || order.HiddenQty != 3 || order.OriginalQty != 7 {
+			t.Fatal("the hidden qty of the maker order should be decremented first", status, order)
+		}
+		if status, _ := ob.GetOrder(small, &order); status != StatusCanceled || order.Qty != 0 {
+			t.Fatal("the taker order should be canceled", status, order)
+		}
+		got := reasons(ob, ch)
+		if got[small] != "canceled/self-trade prevented: decrement_cancel" || got[maker] != "replaced/self-trade prevented: decrement_cancel" {
+			t.Fatal("wrong reports", got)
+		}
+
+		// the smaller maker order is canceled and the taker order rests with the rest of it
+		large, _ := ob.ProcessLimitOrder(Buy, 100, 9, WithAccount("alice"))
+		if status, _ := ob.GetOrder(maker, &order); status != StatusCanceled {
+			t.Fatal("the maker order should be canceled", status)
+		}
+		if status, _ := ob.GetOrder(large, &order); status != StatusPending || order.Qty != 2 || order.OriginalQty != 2 {
+			t.Fatal("the taker order should be decremented", status, order)
+		}
+		if len(ob.GetTrades()) != 0 || len(ob.GetAsks()) != 0 {
+			t.Fatal("the orders of alice should not trade")
+		}
+	})
+	t.Log("... Passed")
+}
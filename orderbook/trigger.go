@@ -0,0 +1,234 @@
+package orderbook
+
+import (
+	"container/list"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WithStopPrice is an option for the stop price of the order, the order rests in the trigger book until the last
+// trade price crosses the stop price: the buy stop is triggered if the last price >= the stop price and the sell
+// stop is triggered if the last price <= the stop price. The limit order becomes a stop-limit order and the market
+// order becomes a stop-market order.
+func WithStopPrice(price int) OrderOption {
+	return func(o *Order) error {
+		if price < 1 {
+			return ErrBadStopPrice
+		}
+		o.StopPrice = price
+		return nil
+	}
+}
+
+// triggerSide is the untriggered stop orders of a side which are grouped by the stop prices, the buy stops are
+// sorted by the stop price ascending and the sell stops are sorted descending, so the first level is the first
+// one to be triggered
+type triggerSide struct {
+	side   Side
+	levels *skiplist
+	index  map[uuid.UUID]*list.Element
+	// accounts is the number of the stop orders of each account
+	accounts accountOrders
+}
+
+// newTriggerSide returns an empty trigger side
+func newTriggerSide(side Side) *triggerSide {
+	less := func(a, b int) bool { return a < b }
+	if side == Sell {
+		less = func(a, b int) bool { return a > b }
+	}
+	return &triggerSide{
+		side:     side,
+		levels:   newSkiplist(less),
+		index:    make(map[uuid.UUID]*list.Element),
+		accounts: make(accountOrders),
+	}
+}
+
+// Len returns the number of the stop orders
+func (t *triggerSide) Len() int { return len(t.index) }
+
+// push pushes the stop order into the level of its stop price
+func (t *triggerSide) push(o *Order) {
+	l := t.levels.get(o.StopPrice)
+	if l == nil {
+		l = newPriceLevel(o.StopPrice)
+		t.levels.insert(l)
+	}
+	t.index[o.ID] = l.push(o)
+	t.accounts.add(o, 1)
+}
+
+// get returns the stop order by id, nil if the order does not exist
+func (t *triggerSide) get(id uuid.UUID) *Order {
+	e, exist := t.index[id]
+	if !exist {
+		return nil
+	}
+	return e.Value.(*Order)
+}
+
+// remove removes the stop order from its level, the empty level is removed as well
+func (t *triggerSide) remove(o *Order) {
+	e, exist := t.index[o.ID]
+	if !exist {
+		return
+	}
+	l := t.levels.get(o.StopPrice)
+	l.remove(e)
+	delete(t.index, o.ID)
+	t.accounts.add(o, -1)
+	if l.orders.Len() == 0 {
+		t.levels.remove(l.price)
+	}
+}
+
+// next returns the first stop order which is triggered by the last trade price, nil if there is none
+func (t *triggerSide) next(last int) *Order {
+	n := t.levels.first()
+	if n == nil {
+		return nil
+	}
+	if (t.side == Buy && n.price <= last) || (t.side == Sell && n.price >= last) {
+		return n.level.orders.Front().Value.(*Order)
+	}
+	return nil
+}
+
+// orders returns the stop orders by priority
+func (t *triggerSide) orders() []*Order {
+	orders := make([]*Order, 0, t.Len())
+	for n := t.levels.first(); n != nil; n = n.next[0] {
+		for e := n.level.orders.Front(); e != nil; e = e.Next() {
+			orders = append(orders, e.Value.(*Order))
+		}
+	}
+	return orders
+}
+
+// triggerBook is the untriggered stop orders of both sides
+type triggerBook struct {
+	buys, sells *triggerSide
+}
+
+// newTriggerBook returns an empty trigger book
+func newTriggerBook() *triggerBook {
+	return &triggerBook{buys: newTriggerSide(Buy), sells: newTriggerSide(Sell)}
+}
+
+// own returns the trigger side of the side
+func (b *triggerBook) own(side Side) *triggerSide {
+	if side == Buy {
+		return b.buys
+	}
+	return b.sells
+}
+
+// get returns the stop order by id, nil if the order does not exist
+func (b *triggerBook) get(id uuid.UUID) *Order {
+	if o := b.buys.get(id); o != nil {
+		return o
+	}
+	return b.sells.get(id)
+}
+
+// next returns the next stop order which is triggered by the last trade price. If the stops of both sides are
+// triggered, the earlier one is the first and the buy stop is prior to the sell stop at the same time.
+func (b *triggerBook) next(last int) *Order {
+	buy, sell := b.buys.next(last), b.sells.next(last)
+	switch {
+	case buy == nil:
+		return sell
+	case sell == nil:
+		return buy
+	case sell.Time.Before(buy.Time):
+		return sell
+	}
+	return buy
+}
+
+// orders returns the stop orders of the buy side and then the sell side by priority
+func (b *triggerBook) orders() []*Order {
+	return append(b.buys.orders(), b.sells.orders()...)
+}
+
+// untriggered checks if the order is the stop order which is still in the trigger book
+func (o Order) untriggered() bool {
+	return o.StopPrice > 0 && !o.Triggered
+}
+
+// GetStops returns the copy of the untriggered stop orders, the buy stops are the first
+func (ob *OrderBook) GetStops() Orders {
+	return copyOrders(ob.loadView().stops)
+}
+
+// submit pushes the stop order into the trigger book or trades the other order, then the stop orders which are
+// triggered by the trades are activated, it should be called with lock
+func (ob *OrderBook) submit(o *Order) error {
+	if o.untriggered() {
+		ob.stops.own(o.Side).push(o)
+	} else if err := ob.processOrder(o); err != nil {
+		return err
+	}
+	ob.triggerStops(o.Time)
+	return nil
+}
+
+// triggerStops activates the stop orders which are triggered by the last trade price at the time of the command.
+// The stops are activated one by one and each of them is traded before the next one is checked against the new
+// last trade price, so the cascades are the same for the same commands.
+func (ob *OrderBook) triggerStops(now time.Time) {
+	if ob.lastPrice == 0 {
+		return
+	}
+	for o := ob.stops.next(ob.lastPrice); o != nil; o = ob.stops.next(ob.lastPrice) {
+		ob.stops.own(o.Side).remove(o)
+		ob.activate(o, now)
+	}
+}
+
+// activate trades the triggered stop order as the limit or market order, it loses its time priority and gets
+// the time of the command which triggers it
+func (ob *OrderBook) activate(o *Order, now time.Time) {
+	o.Triggered = true
+	o.Time = now
+	ob.report(ExecTriggered, o, StatusPending)
+
+	if err := ob.admitTriggered(o); err != nil {
+		ob.Canceled[o.ID.String()] = *o
+		ob.reject(o, err)
+		return
+	}
+	ob.processOrder(o)
+}
+
+// admitTriggered checks the triggered stop order by the price collar at the last trade price of its activation and
+// admit, the rejection is journaled after the command which triggers the stop, so the replayed stop is rejected as
+// well even if the rejection depends on the ledger or the reloaded risk limits
+func (ob *OrderBook) admitTriggered(o *Order) error {
+	if ob.replaying {
+		if reason, exist := ob.rejected[o.ID.String()]; exist {
+			return errors.New(reason)
+		}
+		return ob.admit(o)
+	}
+
+	// the other risk checks are applied when the stop order is submitted
+	var err error
+	if len(ob.riskChecks) > 0 {
+		err = CheckPriceCollar(ob.riskState(o), o)
+	}
+	if err == nil {
+		err = ob.admit(o)
+	}
+	if err == nil {
+		return nil
+	}
+	if jerr := ob.writeJournal(journalEntry{Type: journalReject, ID: o.ID.String(), Reason: err.Error()}); jerr != nil {
+		log.Println("orderbook: failed to journal the rejected stop order:", jerr)
+	}
+	return err
+}
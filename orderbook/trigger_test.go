@@ -0,0 +1,193 @@
+package orderbook
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestStopLimitOrder(t *testing.T) {
+
+	t.Log("start testing the stop-limit order...")
+
+	ob, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ob.Close()
+
+	for _, price := range []int{104, 105, 106, 108} {
+		if _, err := ob.ProcessLimitOrder(Sell, price, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := ob.ProcessLimitOrder(Buy, 100, 10, WithStopPrice(0)); !errors.Is(err, ErrBadStopPrice) {
+		t.Fatal("the bad stop price should be rejected, but got", err)
+	}
+
+	// the buy stop at 105 with the limit 106
+	id, err := ob.ProcessLimitOrder(Buy, 106, 15, WithStopPrice(105))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order Order
+	if status, err := ob.GetOrder(id, &order); err != nil || status != StatusUntriggered || order.Triggered {
+		t.Fatal("the stop order should be untriggered", status, err)
+	}
+	if len(ob.GetStops()) != 1 || len(ob.GetBids()) != 0 {
+		t.Fatal("the stop order should rest in the trigger book")
+	}
+
+	// the last price 104 does not cross the stop price
+	if _, err := ob.ProcessLimitOrder(Buy, 104, 10); err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := ob.GetOrder(id, &order); status != StatusUntriggered {
+		t.Fatal("the stop order should not be triggered at 104", status)
+	}
+
+	// the last price 105 triggers the stop order which takes 105 & 106
+	if _, err := ob.ProcessLimitOrder(Buy, 105, 5); err != nil {
+		t.Fatal(err)
+	}
+	status, err := ob.GetOrder(id, &order)
+	if err != nil || status != StatusCompleted || !order.Triggered {
+		t.Fatal("the stop order should be triggered and completed", status, err)
+	}
+	if len(ob.GetStops()) != 0 {
+		t.Fatal("the trigger book should be empty")
+	}
+	if asks := ob.GetAsks(); len(asks) != 1 || asks[0].Price != 108 {
+		t.Fatal("only the ask at 108 should rest", asks)
+	}
+	t.Log("... Passed")
+}
+
+func TestStopCascade(t *testing.T) {
+
+	t.Log("start testing the cascade of the stop orders...")
+
+	run := func() (*OrderBook, []ExecutionReport) {
+		ob, err := New(WithClock(newTestClock().Now), WithIDGenerator(sequentialIDs()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(ob.Close)
+		ch := ob.SubscribeExecutions(ExecutionFilter{}, 1024)
+
+		for _, price := range []int{100, 99, 98, 97} {
+			if _, err := ob.ProcessLimitOrder(Buy, price, 10); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// the sell stops at 99 & 98, the first one trades through 98 and triggers the second one
+		if _, err := ob.ProcessMarketOrder(Sell, 15, WithStopPrice(99)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ob.ProcessMarketOrder(Sell, 5, WithStopPrice(98)); err != nil {
+			t.Fatal(err)
+		}
+		// the buy stop far from the market is not triggered
+		if _, err := ob.ProcessLimitOrder(Buy, 120, 10, WithStopPrice(110)); err != nil {
+			t.Fatal(err)
+		}
+		// the last price 99 triggers the first stop
+		if _, err := ob.ProcessMarketOrder(Sell, 15); err != nil {
+			t.Fatal(err)
+		}
+
+		ob.UnsubscribeExecutions(ch)
+		reports := make([]ExecutionReport, 0)
+		for r := range ch {
+			reports = append(reports, r)
+		}
+		return ob, reports
+	}
+
+	ob, reports := run()
+	triggered := make([]int, 0)
+	for _, r := range reports {
+		if r.Type == ExecTriggered {
+			triggered = append(triggered, r.Order.StopPrice)
+		}
+	}
+	if len(triggered) != 2 || triggered[0] != 99 || triggered[1] != 98 {
+		t.Fatal("the stops should be triggered at 99 and then 98, but got", triggered)
+	}
+	if bids := ob.GetBids(); len(bids) != 1 || bids[0].Price != 97 || bids[0].Qty != 5 {
+		t.Fatal("the bid 97 should have 5 left", bids)
+	}
+	if stops := ob.GetStops(); len(stops) != 1 || stops[0].StopPrice != 110 {
+		t.Fatal("the buy stop at 110 should rest", stops)
+	}
+
+	// the cascade is the same for the same commands
+	_, again := run()
+	if len(reports) != len(again) {
+		t.Fatalf("the number of the reports should be %d, but got %d", len(reports), len(again))
+	}
+	for i := range reports {
+		if reports[i].Type != again[i].Type || reports[i].Order.ID != again[i].Order.ID || reports[i].Fill.ID != again[i].Fill.ID {
+			t.Fatalf("the report[%d] should be %s, but got %s", i, reports[i], again[i])
+		}
+	}
+	t.Log("... Passed")
+}
+
+func TestStopCancelAmendReplay(t *testing.T) {
+
+	t.Log("start testing the cancel, amend and replay of the stop orders...")
+
+	path := filepath.Join(t.TempDir(), "default.journal")
+	j, err := OpenJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ob, err := New(WithJournal(j))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	canceled, err := ob.ProcessLimitOrder(Sell, 90, 10, WithStopPrice(95))
+	if err != nil {
+		t.Fatal(err)
+	}
+	amended, err := ob.ProcessLimitOrder(Sell, 90, 10, WithStopPrice(94))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.CancelOrder(canceled); err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.AmendOrder(amended, 91, 20); err != nil {
+		t.Fatal(err)
+	}
+
+	var order Order
+	if status, _ := ob.GetOrder(canceled, &order); status != StatusCanceled {
+		t.Fatal("the stop order should be canceled", status)
+	}
+	if status, _ := ob.GetOrder(amended, &order); status != StatusUntriggered || order.Price != 91 || order.Qty != 20 {
+		t.Fatal("the stop order should be amended", status, order)
+	}
+	ob.Close()
+	j.Close()
+
+	j, err = OpenJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	replayed, err := New(WithJournal(j))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replayed.Close()
+	if err := replayed.Replay(); err != nil {
+		t.Fatal(err)
+	}
+	if stops := replayed.GetStops(); len(stops) != 1 || stops[0].ID.String() != amended || stops[0].Qty != 20 {
+		t.Fatal("the amended stop order should be replayed", stops)
+	}
+	t.Log("... Passed")
+}
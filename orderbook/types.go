@@ -3,22 +3,69 @@ package orderbook
 import (
 	"errors"
 	"time"
+
+	"github.com/google/uuid"
 )
 
-var (
-	// MaxQueueSize is the max size of the queue
-	MaxQueueSize int = 100
+const (
+	// DefaultMaxQueueSize is the default max. number of the resting orders of each side of the orderbook
+	DefaultMaxQueueSize = 100
+	// DefaultOrderExpiration is the default live time of the orders and the history of the orderbook
+	DefaultOrderExpiration = 24 * time.Hour
 )
 
 var (
 	ErrBadOrderPrice       error = errors.New("price should be greater than 1")
 	ErrBadOrderQty         error = errors.New("qty should be greater than 1")
+	ErrBadStopPrice        error = errors.New("stop price should be greater than 1")
+	ErrBadDisplayQty       error = errors.New("display qty should be greater than 1")
+	ErrBadPostOnly         error = errors.New("unknown post-only mode")
+	ErrPostOnlyMarket      error = errors.New("market order can not be post-only")
+	ErrPostOnlyWouldTrade  error = errors.New("post-only order would take liquidity")
+	ErrReduceOnly          error = errors.New("reduce-only order can not increase the position")
+	ErrBadSTP              error = errors.New("unknown self-trade prevention mode")
+	ErrSelfTrade           error = errors.New("self-trade prevented")
+	ErrBadProtectionPrice  error = errors.New("protection price should be greater than 0")
+	ErrBadSlippage         error = errors.New("slippage should be greater than 0 basis points")
+	ErrNoLiquidity         error = errors.New("market order has no liquidity to trade")
+	ErrMarketNotFilled     error = errors.New("the rest of the market order is canceled")
+	ErrBadLedger           error = errors.New("ledger is empty")
+	ErrAccountRequired     error = errors.New("account is required")
+	ErrAssetNotFound       error = errors.New("asset not found")
+	ErrBadAmount           error = errors.New("amount should be greater than 0")
+	ErrInsufficientFunds   error = errors.New("insufficient funds")
+	ErrRiskRejected        error = errors.New("rejected by the risk check")
+	ErrBadRiskLimits       error = errors.New("risk limits should not be less than 0")
+	ErrBadTickSize         error = errors.New("price should be a multiple of the tick size")
+	ErrBadLotSize          error = errors.New("qty should be a multiple of the lot size")
+	ErrOrderQtyTooSmall    error = errors.New("qty should not be less than the min qty")
+	ErrOrderQtyTooLarge    error = errors.New("qty should not be greater than the max qty")
+	ErrBadInstrument       error = errors.New("bad instrument")
+	ErrBadDecimal          error = errors.New("bad decimal")
 	ErrTooLargeSizeOfQueue error = errors.New("too large size to create the queue")
+	ErrBadQueueSize        error = errors.New("max size of the queue should be greater than 0")
+	ErrBadOrderExpiration  error = errors.New("order expiration should be greater than 0")
 	ErrDataNotFound        error = errors.New("data not found")
+	ErrBadTimeInForce      error = errors.New("unknown time in force")
+	ErrBadExpireTime       error = errors.New("expire time should be later than now")
+	ErrBadSessionEnd       error = errors.New("session end should be in [0, 24h)")
+	ErrOrderNotFilled      error = errors.New("the fill or kill order can not be filled completely")
+	ErrTradingHalted       error = errors.New("trading is halted")
+	ErrBadSymbol           error = errors.New("symbol is empty")
+	ErrSymbolExists        error = errors.New("symbol already exists")
+	ErrSymbolNotFound      error = errors.New("symbol not found")
+	ErrBadSyncPolicy       error = errors.New("unknown sync policy of the journal")
+	ErrBadSyncInterval     error = errors.New("sync interval of the journal should be greater than 0")
+	ErrBadJournal          error = errors.New("bad journal")
+	ErrBadSnapshot         error = errors.New("bad snapshot")
+	ErrBadSnapshotVersion  error = errors.New("unsupported version of the snapshot")
+	ErrBadSnapshotInterval error = errors.New("snapshot interval should not be less than 0")
+	ErrSnapshotDisabled    error = errors.New("snapshot is disabled")
+	ErrBadClock            error = errors.New("clock is empty")
+	ErrBadIDGenerator      error = errors.New("id generator is empty")
+	ErrOrderBookClosed     error = errors.New("orderbook is closed")
 )
 
-var OrderExpiration time.Duration = 86400 * time.Second // 1 day
-
 // Side is the type of order side
 type Side int
 
@@ -37,6 +84,15 @@ func (s Side) String() string {
 // Option is an option type for OrderBook
 type Option func(ob *OrderBook) error
 
+// OrderOption is an option type for Order
+type OrderOption func(o *Order) error
+
+// Clock returns the current time of the orderbook
+type Clock func() time.Time
+
+// IDGenerator returns the id of the new order
+type IDGenerator func() uuid.UUID
+
 // PriceMode is the mode of price of the order
 type PriceMode int
 
@@ -61,6 +117,9 @@ const (
 	StatusCompleted
 	StatusPending
 	StatusCanceled
+	StatusPartiallyFilled
+	StatusRejected
+	StatusUntriggered
 	StatusUnknown
 )
 
@@ -70,6 +129,61 @@ func (o OrderStatus) String() string {
 		"completed",
 		"pending",
 		"canceled",
+		"partially_filled",
+		"rejected",
+		"untriggered",
 		"unknown",
 	}[o]
 }
+
+// TimeInForce is how long the order remains in the queue
+type TimeInForce int
+
+const (
+	// GTC (good till canceled) order remains in the queue until it is filled, canceled or expired by the auto-cleaner
+	GTC TimeInForce = iota
+	// IOC (immediate or cancel) order cancels the unfilled part after trading
+	IOC
+	// FOK (fill or kill) order is rejected if it can not be filled completely
+	FOK
+	// GTD (good till date) order is expired at its expire time
+	GTD
+	// Day order is expired at the end of the session
+	Day
+)
+
+func (t TimeInForce) String() string {
+	if t < GTC || t > Day {
+		return "unknown"
+	}
+	return [...]string{
+		"gtc",
+		"ioc",
+		"fok",
+		"gtd",
+		"day",
+	}[t]
+}
+
+// PostOnly is how the post-only order is handled if it would take liquidity
+type PostOnly int
+
+const (
+	// PostOnlyNone is the order which can take liquidity
+	PostOnlyNone PostOnly = iota
+	// PostOnlyReject rejects the order if it would cross the spread
+	PostOnlyReject
+	// PostOnlySlide reprices the order one tick away from the best opposite price if it would cross the spread
+	PostOnlySlide
+)
+
+func (p PostOnly) String() string {
+	if p < PostOnlyNone || p > PostOnlySlide {
+		return "unknown"
+	}
+	return [...]string{
+		"none",
+		"reject",
+		"slide",
+	}[p]
+}
@@ -6,13 +6,20 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"math"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"mytrader.github.com/orderbook"
 	pb "mytrader.github.com/service/protoc"
+	"mytrader.github.com/service/server"
 )
 
 var orderBookSide = map[string]orderbook.Side{
@@ -25,47 +32,137 @@ var orderBookPriceMode = map[string]orderbook.PriceMode{
 	"market": orderbook.Market,
 }
 
+var orderBookPostOnly = map[string]orderbook.PostOnly{
+	"":       orderbook.PostOnlyNone,
+	"reject": orderbook.PostOnlyReject,
+	"slide":  orderbook.PostOnlySlide,
+}
+
+var orderBookTimeInForce = map[string]orderbook.TimeInForce{
+	"gtc": orderbook.GTC,
+	"ioc": orderbook.IOC,
+	"fok": orderbook.FOK,
+	"gtd": orderbook.GTD,
+	"day": orderbook.Day,
+}
+
 func main() {
 
 	var (
 		serverAddr string
 		call       string
-		qty        int64
-		price      int64
+		qty        string
+		price      string
+		stopPrice  string
+		displayQty string
+		protection string
+		slippage   int
+		postOnly   string
+		reduceOnly bool
+		stp        string
+
+		side        string
+		priceMode   string
+		timeInForce string
+		expireTime  int64
 
-		side      string
-		priceMode string
+		oid     string
+		symbol  string
+		levels  int
+		account string
 
-		oid string
+		asset  string
+		amount string
+
+		useTLS        bool
+		tlsCA         string
+		tlsCert       string
+		tlsKey        string
+		tlsServerName string
+		apiKey        string
+		apiSecret     string
+
+		maxQueueSize    int
+		orderExpiration int64
 	)
 
 	flag.StringVar(&serverAddr, "server-addr", "localhost:9999", "the address of the server")
-	flag.StringVar(&call, "call", "", "call for server [create_order|get_order]")
+	flag.StringVar(&call, "call", "", "call for server [create_order|get_order|cancel_order|amend_order|get_depth|get_instrument|get_position|deposit|withdraw|get_balance|subscribe_market_data|subscribe_executions], or admin call [halt|resume|cancel_all|set_settings|snapshot|dump] of the symbol or all the symbols if it is empty")
 	flag.StringVar(&oid, "order_id", "", "order id")
-	flag.Int64Var(&qty, "quantity", -1, "quantity of the the order")
+	flag.StringVar(&symbol, "symbol", "default", "symbol of the order")
+	flag.StringVar(&account, "account", "", "account of the order, or the filter of the execution reports")
+	flag.StringVar(&asset, "asset", "", "asset of the balance, all the assets of the account if it is empty")
+	flag.StringVar(&amount, "amount", "", "decimal amount of the deposit or withdraw")
+	flag.IntVar(&levels, "levels", 10, "number of price levels of each side, all levels if it is less than 1")
+	flag.StringVar(&qty, "quantity", "", "decimal quantity of the the order, e.g. 0.001")
 	flag.StringVar(&side, "side", "", "side of the order [buy|sell]")
-	flag.Int64Var(&price, "price", -1, "price of the order")
+	flag.StringVar(&price, "price", "", "decimal price of the order, e.g. 101.25")
+	flag.StringVar(&stopPrice, "stop_price", "", "decimal stop price of the stop-limit or stop-market order, the order is triggered when the last trade price crosses it")
+	flag.StringVar(&displayQty, "display_quantity", "", "decimal display quantity of the iceberg order, only the slices of it are shown in the book")
+	flag.StringVar(&protection, "protection_price", "", "decimal worst price of the market order, the rest of the order is canceled instead of trading beyond it")
+	flag.IntVar(&slippage, "max_slippage", 0, "max. slippage of the market order from the best opposite price in basis points, the default of the server if it is 0")
+	flag.StringVar(&postOnly, "post_only", "", "post-only mode of the limit order [reject|slide], the order is rejected or slid one tick away if it would take liquidity")
+	flag.BoolVar(&reduceOnly, "reduce_only", false, "the order can only shrink the position of the account")
+	flag.StringVar(&stp, "stp", "", "self-trade prevention mode of the order [cancel_newest|cancel_oldest|cancel_both|decrement_cancel], the default mode of the orderbook if it is empty")
 	flag.StringVar(&priceMode, "price_mode", "", "price mode of the order [market|limit]")
+	flag.StringVar(&timeInForce, "time_in_force", "gtc", "time in force of the order [gtc|ioc|fok|gtd|day]")
+	flag.Int64Var(&expireTime, "expire_time", 0, "unix timestamp of the expiration of the gtd order")
+	flag.BoolVar(&useTLS, "tls", false, "connect to the server by TLS, it is enabled by the TLS files too")
+	flag.StringVar(&tlsCA, "tls_ca", "", "CA file of the server certificate, the system CAs if it is empty")
+	flag.StringVar(&tlsCert, "tls_cert", "", "certificate file of the client for mTLS")
+	flag.StringVar(&tlsKey, "tls_key", "", "key file of the certificate of the client for mTLS")
+	flag.StringVar(&tlsServerName, "tls_server_name", "", "server name to verify the server certificate, the host of the address if it is empty")
+	flag.StringVar(&apiKey, "api_key", "", "api key of the client")
+	flag.StringVar(&apiSecret, "api_secret", "", "secret of the api key, the calls are signed by HMAC-SHA256 if it is set")
+	flag.IntVar(&maxQueueSize, "max_queue_size", 0, "new max. size of the queue of each side of set_settings, it is not changed if it is 0")
+	flag.Int64Var(&orderExpiration, "order_expiration", 0, "new expiration of the orders in second of set_settings, it is not changed if it is 0")
 	flag.Parse()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	conn, err := grpc.DialContext(ctx, serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds := insecure.NewCredentials()
+	if useTLS || len(tlsCA) > 0 || len(tlsCert) > 0 {
+		tlsCreds, err := server.ClientTLS(tlsCA, tlsCert, tlsKey, tlsServerName)
+		if err != nil {
+			panic(err)
+		}
+		creds = tlsCreds
+	}
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if len(apiKey) > 0 {
+		dialOpts = append(dialOpts, server.AuthDialOptions(apiKey, apiSecret)...)
+	}
+	conn, err := grpc.DialContext(ctx, serverAddr, dialOpts...)
 	if err != nil {
 		panic(err)
 	}
 	defer conn.Close()
 
 	client := pb.NewTraderClient(conn)
+	admin := pb.NewAdminClient(conn)
 
 	switch call {
 	case "create_order":
 
-		o, err := createTradeOrder(side, priceMode, int64(price), int64(qty))
+		o, err := createTradeOrder(side, priceMode, timeInForce, price, qty, expireTime)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
+		o.Symbol = symbol
+		o.Account = account
+		o.DecimalStopPrice = stopPrice
+		o.DecimalDisplayQuantity = displayQty
+		o.DecimalProtectionPrice = protection
+		o.MaxSlippage = int32(slippage)
+		o.ReduceOnly = reduceOnly
+		o.Stp = stp
+		po, exist := orderBookPostOnly[postOnly]
+		if !exist {
+			fmt.Println("bad post_only value, it should be reject or slide")
+			os.Exit(1)
+		}
+		o.PostOnly = int32(po)
 
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 		defer cancel()
@@ -86,15 +183,207 @@ func main() {
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 		defer cancel()
 
-		reply, err := client.Get(ctx, &pb.GetOrder{Id: oid})
+		reply, err := client.Get(ctx, &pb.GetOrder{Id: oid, Symbol: symbol})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		printReply(reply)
+
+	case "cancel_order":
+		if len(oid) == 0 {
+			fmt.Println("id is empty")
+			os.Exit(0)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		reply, err := client.Cancel(ctx, &pb.CancelOrder{Id: oid, Symbol: symbol})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		printReply(reply)
+
+	case "amend_order":
+		if len(oid) == 0 {
+			fmt.Println("id is empty")
+			os.Exit(0)
+		}
+		if len(qty) == 0 {
+			fmt.Println("quantity is empty")
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		reply, err := client.Amend(ctx, &pb.AmendOrder{Id: oid, DecimalPrice: price, DecimalQuantity: qty, Symbol: symbol})
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
 		printReply(reply)
 
+	case "get_depth":
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		reply, err := client.GetDepth(ctx, &pb.DepthRequest{Symbol: symbol, Levels: int32(levels)})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		printDepth(reply)
+
+	case "get_instrument":
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		reply, err := client.GetInstrument(ctx, &pb.InstrumentRequest{Symbol: symbol})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		printInstrument(reply)
+
+	case "get_position":
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		reply, err := client.GetPosition(ctx, &pb.PositionRequest{Symbol: symbol, Account: account})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		log.Println("response from server => ")
+		fmt.Printf("symbol: %s, account: %s, position: %s\n", reply.Symbol, reply.Account, decimal(reply.Quantity, reply.QuantityScale))
+
+	case "deposit", "withdraw":
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		update := client.Deposit
+		if call == "withdraw" {
+			update = client.Withdraw
+		}
+		reply, err := update(ctx, &pb.BalanceRequest{Account: account, Asset: asset, DecimalAmount: amount})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		log.Println("response from server => ")
+		printBalance(reply)
+
+	case "get_balance":
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		reply, err := client.GetBalance(ctx, &pb.BalanceRequest{Account: account, Asset: asset})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		log.Println("response from server => ")
+		for _, b := range reply.Balances {
+			printBalance(b)
+		}
+
+	case "subscribe_market_data":
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		// the prices and quantities of the market data are formatted by the scales of the instrument
+		inst, err := client.GetInstrument(ctx, &pb.InstrumentRequest{Symbol: symbol})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		stream, err := client.SubscribeMarketData(ctx, &pb.MarketDataRequest{Symbol: symbol, Levels: int32(levels)})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for {
+			md, err := stream.Recv()
+			if err != nil {
+				if status.Code(err) != codes.Canceled {
+					fmt.Println(err)
+				}
+				return
+			}
+			printMarketData(inst, md)
+		}
+
+	case "subscribe_executions":
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		stream, err := client.SubscribeExecutions(ctx, &pb.ExecutionRequest{Symbol: symbol, OrderId: oid, Account: account})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for {
+			er, err := stream.Recv()
+			if err != nil {
+				if status.Code(err) != codes.Canceled {
+					fmt.Println(err)
+				}
+				return
+			}
+			printExecutionReport(er)
+		}
+
+	case "halt", "resume", "cancel_all", "set_settings", "snapshot":
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var reply *pb.AdminReply
+		var err error
+		switch call {
+		case "halt":
+			reply, err = admin.Halt(ctx, &pb.AdminRequest{Symbol: symbol})
+		case "resume":
+			reply, err = admin.Resume(ctx, &pb.AdminRequest{Symbol: symbol})
+		case "cancel_all":
+			reply, err = admin.CancelAll(ctx, &pb.CancelAllRequest{Symbol: symbol, Side: side, Account: account})
+		case "set_settings":
+			reply, err = admin.SetSettings(ctx, &pb.SettingsRequest{Symbol: symbol, MaxQueueSize: int32(maxQueueSize), OrderExpiration: orderExpiration})
+		case "snapshot":
+			reply, err = admin.Snapshot(ctx, &pb.AdminRequest{Symbol: symbol})
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		log.Println("response from server => ")
+		for _, state := range reply.Books {
+			printBookState(state)
+		}
+
+	case "dump":
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		reply, err := admin.Dump(ctx, &pb.AdminRequest{Symbol: symbol})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		printBookState(reply.State)
+		fmt.Println("last price:", decimal(reply.LastPrice, reply.PriceScale))
+		for _, orders := range []struct {
+			name   string
+			orders []*pb.OrderReply
+		}{{"bids", reply.Bids}, {"asks", reply.Asks}, {"stops", reply.Stops}} {
+			fmt.Printf("===> %s: %d\n", orders.name, len(orders.orders))
+			for _, o := range orders.orders {
+				printReply(o)
+			}
+		}
+
 	default:
-		fmt.Println("unkonwn command [create_order, ger_order]", call)
+		fmt.Println("unkonwn command [create_order, ger_order, cancel_order, amend_order, get_depth, get_instrument, get_position, deposit, withdraw, get_balance, subscribe_market_data, subscribe_executions, halt, resume, cancel_all, set_settings, snapshot, dump]", call)
 		os.Exit(0)
 	}
 
@@ -103,13 +392,110 @@ func main() {
 func printReply(reply *pb.OrderReply) {
 	log.Println("response from server => ")
 	fmt.Println("order_id:", reply.ID)
+	fmt.Println("symbol:", reply.Symbol)
+	if len(reply.Account) > 0 {
+		fmt.Println("account:", reply.Account)
+	}
 	fmt.Println("timestamp:", reply.Timestamp)
-	fmt.Printf("side: %s, price mode: %s\n", reply.Side, reply.PriceMode)
-	fmt.Printf("price: %d, quantity: %d\n", reply.Price, reply.Quantity)
+	fmt.Printf("side: %s, price mode: %s, time in force: %s\n", reply.Side, reply.PriceMode, reply.TimeInForce)
+	if reply.ExpireTime > 0 {
+		fmt.Println("expire time:", reply.ExpireTime)
+	}
+	p, q := reply.PriceScale, reply.QuantityScale
+	if reply.StopPrice > 0 {
+		fmt.Printf("stop price: %s, triggered: %t\n", decimal(reply.StopPrice, p), reply.Triggered)
+	}
+	if reply.PostOnly != orderbook.PostOnlyNone.String() || reply.ReduceOnly {
+		fmt.Printf("post only: %s, reduce only: %t\n", reply.PostOnly, reply.ReduceOnly)
+	}
+	if reply.ProtectionPrice > 0 {
+		fmt.Println("protection price:", decimal(reply.ProtectionPrice, p))
+	}
+	if reply.Stp != orderbook.STPNone.String() {
+		fmt.Println("self-trade prevention:", reply.Stp)
+	}
+	if reply.DisplayQuantity > 0 {
+		fmt.Printf("display quantity: %s, hidden quantity: %s\n", decimal(reply.DisplayQuantity, q), decimal(reply.HiddenQuantity, q))
+	}
+	fmt.Printf("price: %s, quantity: %s\n", decimal(reply.Price, p), decimal(reply.Quantity, q))
+	fmt.Printf("original: %s, filled: %s, remaining: %s, average price: %s\n",
+		decimal(reply.OriginalQuantity, q), decimal(reply.FilledQuantity, q), decimal(reply.RemainingQuantity, q),
+		averagePrice(reply.AveragePrice, p))
 	fmt.Println("status:", reply.Status)
 }
 
-func createTradeOrder(side, priceMode string, price, qty int64) (*pb.Order, error) {
+// printDepth prints the ladder of the depth, the asks are on the top and the best prices are in the middle
+func printDepth(reply *pb.DepthReply) {
+	log.Println("response from server => ")
+	fmt.Println("symbol:", reply.Symbol)
+	p, q := reply.PriceScale, reply.QuantityScale
+	fmt.Printf("%8s %12s %12s\n", "side", "price", "quantity(orders)")
+	for i := len(reply.Asks) - 1; i >= 0; i-- {
+		l := reply.Asks[i]
+		fmt.Printf("%8s %12s %12s(%d)\n", "ask", decimal(l.Price, p), decimal(l.Quantity, q), l.Orders)
+	}
+	fmt.Println("---------------------------------------")
+	for _, l := range reply.Bids {
+		fmt.Printf("%8s %12s %12s(%d)\n", "bid", decimal(l.Price, p), decimal(l.Quantity, q), l.Orders)
+	}
+}
+
+// printInstrument prints the spec. of the prices and quantities of the symbol
+func printInstrument(inst *pb.Instrument) {
+	log.Println("response from server => ")
+	p, q := inst.PriceScale, inst.QuantityScale
+	fmt.Println("symbol:", inst.Symbol)
+	fmt.Printf("price scale: %d, quantity scale: %d\n", p, q)
+	fmt.Printf("tick size: %s, lot size: %s\n", decimal(inst.TickSize, p), decimal(inst.LotSize, q))
+	fmt.Printf("min. quantity: %s, max. quantity: %s\n", decimal(inst.MinQuantity, q), decimal(inst.MaxQuantity, q))
+}
+
+// decimal formats the price or quantity in the smallest units of the scale
+func decimal(v int64, scale int32) string {
+	return orderbook.FormatDecimal(v, int(scale))
+}
+
+// averagePrice formats the average price in the smallest units of the scale
+func averagePrice(v float64, scale int32) string {
+	return strconv.FormatFloat(v/math.Pow10(int(scale)), 'f', -1, 64)
+}
+
+// printMarketData prints the market data of the instrument by its type
+func printMarketData(inst *pb.Instrument, md *pb.MarketData) {
+	p, q := inst.PriceScale, inst.QuantityScale
+	switch e := md.Event.(type) {
+	case *pb.MarketData_Snapshot:
+		fmt.Printf("[%d] snapshot:\n", md.Seq)
+		printDepth(e.Snapshot)
+	case *pb.MarketData_Top:
+		fmt.Printf("[%d] top: bid %s x %s, ask %s x %s\n", md.Seq,
+			decimal(e.Top.Bid.Price, p), decimal(e.Top.Bid.Quantity, q), decimal(e.Top.Ask.Price, p), decimal(e.Top.Ask.Quantity, q))
+	case *pb.MarketData_Level:
+		fmt.Printf("[%d] level: %s %s x %s(%d)\n",
+			md.Seq, e.Level.Side, decimal(e.Level.Level.Price, p), decimal(e.Level.Level.Quantity, q), e.Level.Level.Orders)
+	case *pb.MarketData_Trade:
+		fmt.Printf("[%d] trade: %s %s x %s, maker: %s, taker: %s\n", md.Seq, e.Trade.AggressorSide,
+			decimal(e.Trade.Price, p), decimal(e.Trade.Quantity, q), e.Trade.MakerOrderID, e.Trade.TakerOrderID)
+	}
+}
+
+// printExecutionReport prints the execution report in one line
+func printExecutionReport(er *pb.ExecutionReport) {
+	o := er.Order
+	p, q := o.PriceScale, o.QuantityScale
+	fmt.Printf("[%d] %s: order %s(%s) %s %s, filled: %s/%s, average price: %s, status: %s",
+		er.Seq, er.Type, o.ID, o.Account, o.Side, decimal(o.Price, p), decimal(o.FilledQuantity, q),
+		decimal(o.OriginalQuantity, q), averagePrice(o.AveragePrice, p), o.Status)
+	if er.Fill != nil {
+		fmt.Printf(", fill: %s x %s", decimal(er.Fill.Price, p), decimal(er.Fill.Quantity, q))
+	}
+	if len(er.Reason) > 0 {
+		fmt.Printf(", reason: %s", er.Reason)
+	}
+	fmt.Println()
+}
+
+func createTradeOrder(side, priceMode, timeInForce, price, qty string, expireTime int64) (*pb.Order, error) {
 
 	s, exist := orderBookSide[side]
 	if !exist {
@@ -121,13 +507,43 @@ func createTradeOrder(side, priceMode string, price, qty int64) (*pb.Order, erro
 		return nil, errors.New("bad price_mode value, it should be market or limit")
 	}
 
-	if pm == orderbook.Limit && price < 1 {
-		return nil, errors.New("price should be >= 1")
+	tif, exist := orderBookTimeInForce[timeInForce]
+	if !exist {
+		return nil, errors.New("bad time_in_force value, it should be gtc, ioc, fok, gtd or day")
 	}
-	if qty < 1 {
-		return nil, errors.New("quantity should be >= 1")
+
+	if pm == orderbook.Limit && len(price) == 0 {
+		return nil, errors.New("price is required by the limit order")
+	}
+	if len(qty) == 0 {
+		return nil, errors.New("quantity is empty")
+	}
+	if tif == orderbook.GTD && expireTime < 1 {
+		return nil, errors.New("expire_time is required by the gtd order")
 	}
 
-	o := &pb.Order{Side: int32(s), PriceMode: int32(pm), Price: price, Quantity: qty}
+	o := &pb.Order{
+		Side:            int32(s),
+		PriceMode:       int32(pm),
+		DecimalPrice:    price,
+		DecimalQuantity: qty,
+		TimeInForce:     int32(tif),
+		ExpireTime:      expireTime,
+	}
 	return o, nil
 }
+
+// printBalance prints the balance in decimals
+func printBalance(b *pb.Balance) {
+	fmt.Printf("account: %s, asset: %s, available: %s, held: %s\n",
+		b.Account, b.Asset, decimal(b.Available, b.Scale), decimal(b.Held, b.Scale))
+}
+
+// printBookState prints the state of the orderbook of the admin calls
+func printBookState(state *pb.BookState) {
+	fmt.Printf("symbol: %s, halted: %t, max. queue size: %d, order expiration: %ds", state.Symbol, state.Halted, state.MaxQueueSize, state.OrderExpiration)
+	if state.Canceled > 0 {
+		fmt.Printf(", canceled: %d", state.Canceled)
+	}
+	fmt.Println()
+}
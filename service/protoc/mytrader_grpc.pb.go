@@ -24,6 +24,16 @@ const _ = grpc.SupportPackageIsVersion7
 type TraderClient interface {
 	Create(ctx context.Context, in *Order, opts ...grpc.CallOption) (*OrderReply, error)
 	Get(ctx context.Context, in *GetOrder, opts ...grpc.CallOption) (*OrderReply, error)
+	Cancel(ctx context.Context, in *CancelOrder, opts ...grpc.CallOption) (*OrderReply, error)
+	Amend(ctx context.Context, in *AmendOrder, opts ...grpc.CallOption) (*OrderReply, error)
+	GetDepth(ctx context.Context, in *DepthRequest, opts ...grpc.CallOption) (*DepthReply, error)
+	GetInstrument(ctx context.Context, in *InstrumentRequest, opts ...grpc.CallOption) (*Instrument, error)
+	GetPosition(ctx context.Context, in *PositionRequest, opts ...grpc.CallOption) (*Position, error)
+	Deposit(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*Balance, error)
+	Withdraw(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*Balance, error)
+	GetBalance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*Balances, error)
+	SubscribeMarketData(ctx context.Context, in *MarketDataRequest, opts ...grpc.CallOption) (Trader_SubscribeMarketDataClient, error)
+	SubscribeExecutions(ctx context.Context, in *ExecutionRequest, opts ...grpc.CallOption) (Trader_SubscribeExecutionsClient, error)
 }
 
 type traderClient struct {
@@ -52,12 +62,158 @@ func (c *traderClient) Get(ctx context.Context, in *GetOrder, opts ...grpc.CallO
 	return out, nil
 }
 
+func (c *traderClient) Cancel(ctx context.Context, in *CancelOrder, opts ...grpc.CallOption) (*OrderReply, error) {
+	out := new(OrderReply)
+	err := c.cc.Invoke(ctx, "/Trader/Cancel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) Amend(ctx context.Context, in *AmendOrder, opts ...grpc.CallOption) (*OrderReply, error) {
+	out := new(OrderReply)
+	err := c.cc.Invoke(ctx, "/Trader/Amend", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) GetDepth(ctx context.Context, in *DepthRequest, opts ...grpc.CallOption) (*DepthReply, error) {
+	out := new(DepthReply)
+	err := c.cc.Invoke(ctx, "/Trader/GetDepth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) GetInstrument(ctx context.Context, in *InstrumentRequest, opts ...grpc.CallOption) (*Instrument, error) {
+	out := new(Instrument)
+	err := c.cc.Invoke(ctx, "/Trader/GetInstrument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) GetPosition(ctx context.Context, in *PositionRequest, opts ...grpc.CallOption) (*Position, error) {
+	out := new(Position)
+	err := c.cc.Invoke(ctx, "/Trader/GetPosition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) Deposit(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*Balance, error) {
+	out := new(Balance)
+	err := c.cc.Invoke(ctx, "/Trader/Deposit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) Withdraw(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*Balance, error) {
+	out := new(Balance)
+	err := c.cc.Invoke(ctx, "/Trader/Withdraw", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) GetBalance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*Balances, error) {
+	out := new(Balances)
+	err := c.cc.Invoke(ctx, "/Trader/GetBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *traderClient) SubscribeMarketData(ctx context.Context, in *MarketDataRequest, opts ...grpc.CallOption) (Trader_SubscribeMarketDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Trader_ServiceDesc.Streams[0], "/Trader/SubscribeMarketData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &traderSubscribeMarketDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Trader_SubscribeMarketDataClient interface {
+	Recv() (*MarketData, error)
+	grpc.ClientStream
+}
+
+type traderSubscribeMarketDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *traderSubscribeMarketDataClient) Recv() (*MarketData, error) {
+	m := new(MarketData)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *traderClient) SubscribeExecutions(ctx context.Context, in *ExecutionRequest, opts ...grpc.CallOption) (Trader_SubscribeExecutionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Trader_ServiceDesc.Streams[1], "/Trader/SubscribeExecutions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &traderSubscribeExecutionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Trader_SubscribeExecutionsClient interface {
+	Recv() (*ExecutionReport, error)
+	grpc.ClientStream
+}
+
+type traderSubscribeExecutionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *traderSubscribeExecutionsClient) Recv() (*ExecutionReport, error) {
+	m := new(ExecutionReport)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TraderServer is the server API for Trader service.
 // All implementations must embed UnimplementedTraderServer
 // for forward compatibility
 type TraderServer interface {
 	Create(context.Context, *Order) (*OrderReply, error)
 	Get(context.Context, *GetOrder) (*OrderReply, error)
+	Cancel(context.Context, *CancelOrder) (*OrderReply, error)
+	Amend(context.Context, *AmendOrder) (*OrderReply, error)
+	GetDepth(context.Context, *DepthRequest) (*DepthReply, error)
+	GetInstrument(context.Context, *InstrumentRequest) (*Instrument, error)
+	GetPosition(context.Context, *PositionRequest) (*Position, error)
+	Deposit(context.Context, *BalanceRequest) (*Balance, error)
+	Withdraw(context.Context, *BalanceRequest) (*Balance, error)
+	GetBalance(context.Context, *BalanceRequest) (*Balances, error)
+	SubscribeMarketData(*MarketDataRequest, Trader_SubscribeMarketDataServer) error
+	SubscribeExecutions(*ExecutionRequest, Trader_SubscribeExecutionsServer) error
 	mustEmbedUnimplementedTraderServer()
 }
 
@@ -71,6 +227,36 @@ func (UnimplementedTraderServer) Create(context.Context, *Order) (*OrderReply, e
 func (UnimplementedTraderServer) Get(context.Context, *GetOrder) (*OrderReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedTraderServer) Cancel(context.Context, *CancelOrder) (*OrderReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
+func (UnimplementedTraderServer) Amend(context.Context, *AmendOrder) (*OrderReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Amend not implemented")
+}
+func (UnimplementedTraderServer) GetDepth(context.Context, *DepthRequest) (*DepthReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDepth not implemented")
+}
+func (UnimplementedTraderServer) GetInstrument(context.Context, *InstrumentRequest) (*Instrument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstrument not implemented")
+}
+func (UnimplementedTraderServer) GetPosition(context.Context, *PositionRequest) (*Position, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPosition not implemented")
+}
+func (UnimplementedTraderServer) Deposit(context.Context, *BalanceRequest) (*Balance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deposit not implemented")
+}
+func (UnimplementedTraderServer) Withdraw(context.Context, *BalanceRequest) (*Balance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Withdraw not implemented")
+}
+func (UnimplementedTraderServer) GetBalance(context.Context, *BalanceRequest) (*Balances, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedTraderServer) SubscribeMarketData(*MarketDataRequest, Trader_SubscribeMarketDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMarketData not implemented")
+}
+func (UnimplementedTraderServer) SubscribeExecutions(*ExecutionRequest, Trader_SubscribeExecutionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeExecutions not implemented")
+}
 func (UnimplementedTraderServer) mustEmbedUnimplementedTraderServer() {}
 
 // UnsafeTraderServer may be embedded to opt out of forward compatibility for this service.
@@ -120,6 +306,192 @@ func _Trader_Get_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Trader_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Trader/Cancel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).Cancel(ctx, req.(*CancelOrder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_Amend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AmendOrder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).Amend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Trader/Amend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).Amend(ctx, req.(*AmendOrder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_GetDepth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).GetDepth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Trader/GetDepth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).GetDepth(ctx, req.(*DepthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_GetInstrument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstrumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).GetInstrument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Trader/GetInstrument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).GetInstrument(ctx, req.(*InstrumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_GetPosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PositionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).GetPosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Trader/GetPosition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).GetPosition(ctx, req.(*PositionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_Deposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).Deposit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Trader/Deposit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).Deposit(ctx, req.(*BalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_Withdraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).Withdraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Trader/Withdraw",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).Withdraw(ctx, req.(*BalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TraderServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Trader/GetBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TraderServer).GetBalance(ctx, req.(*BalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trader_SubscribeMarketData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MarketDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TraderServer).SubscribeMarketData(m, &traderSubscribeMarketDataServer{stream})
+}
+
+type Trader_SubscribeMarketDataServer interface {
+	Send(*MarketData) error
+	grpc.ServerStream
+}
+
+type traderSubscribeMarketDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *traderSubscribeMarketDataServer) Send(m *MarketData) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Trader_SubscribeExecutions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecutionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TraderServer).SubscribeExecutions(m, &traderSubscribeExecutionsServer{stream})
+}
+
+type Trader_SubscribeExecutionsServer interface {
+	Send(*ExecutionReport) error
+	grpc.ServerStream
+}
+
+type traderSubscribeExecutionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *traderSubscribeExecutionsServer) Send(m *ExecutionReport) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Trader_ServiceDesc is the grpc.ServiceDesc for Trader service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -135,6 +507,315 @@ var Trader_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _Trader_Get_Handler,
 		},
+		{
+			MethodName: "Cancel",
+			Handler:    _Trader_Cancel_Handler,
+		},
+		{
+			MethodName: "Amend",
+			Handler:    _Trader_Amend_Handler,
+		},
+		{
+			MethodName: "GetDepth",
+			Handler:    _Trader_GetDepth_Handler,
+		},
+		{
+			MethodName: "GetInstrument",
+			Handler:    _Trader_GetInstrument_Handler,
+		},
+		{
+			MethodName: "GetPosition",
+			Handler:    _Trader_GetPosition_Handler,
+		},
+		{
+			MethodName: "Deposit",
+			Handler:    _Trader_Deposit_Handler,
+		},
+		{
+			MethodName: "Withdraw",
+			Handler:    _Trader_Withdraw_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _Trader_GetBalance_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeMarketData",
+			Handler:       _Trader_SubscribeMarketData_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeExecutions",
+			Handler:       _Trader_SubscribeExecutions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mytrader.proto",
+}
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminClient interface {
+	Halt(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error)
+	Resume(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error)
+	CancelAll(ctx context.Context, in *CancelAllRequest, opts ...grpc.CallOption) (*AdminReply, error)
+	SetSettings(ctx context.Context, in *SettingsRequest, opts ...grpc.CallOption) (*AdminReply, error)
+	Snapshot(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error)
+	Dump(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*BookDump, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) Halt(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error) {
+	out := new(AdminReply)
+	err := c.cc.Invoke(ctx, "/Admin/Halt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) Resume(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error) {
+	out := new(AdminReply)
+	err := c.cc.Invoke(ctx, "/Admin/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) CancelAll(ctx context.Context, in *CancelAllRequest, opts ...grpc.CallOption) (*AdminReply, error) {
+	out := new(AdminReply)
+	err := c.cc.Invoke(ctx, "/Admin/CancelAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetSettings(ctx context.Context, in *SettingsRequest, opts ...grpc.CallOption) (*AdminReply, error) {
+	out := new(AdminReply)
+	err := c.cc.Invoke(ctx, "/Admin/SetSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) Snapshot(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error) {
+	out := new(AdminReply)
+	err := c.cc.Invoke(ctx, "/Admin/Snapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) Dump(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*BookDump, error) {
+	out := new(BookDump)
+	err := c.cc.Invoke(ctx, "/Admin/Dump", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility
+type AdminServer interface {
+	Halt(context.Context, *AdminRequest) (*AdminReply, error)
+	Resume(context.Context, *AdminRequest) (*AdminReply, error)
+	CancelAll(context.Context, *CancelAllRequest) (*AdminReply, error)
+	SetSettings(context.Context, *SettingsRequest) (*AdminReply, error)
+	Snapshot(context.Context, *AdminRequest) (*AdminReply, error)
+	Dump(context.Context, *AdminRequest) (*BookDump, error)
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (UnimplementedAdminServer) Halt(context.Context, *AdminRequest) (*AdminReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Halt not implemented")
+}
+func (UnimplementedAdminServer) Resume(context.Context, *AdminRequest) (*AdminReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedAdminServer) CancelAll(context.Context, *CancelAllRequest) (*AdminReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAll not implemented")
+}
+func (UnimplementedAdminServer) SetSettings(context.Context, *SettingsRequest) (*AdminReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSettings not implemented")
+}
+func (UnimplementedAdminServer) Snapshot(context.Context, *AdminRequest) (*AdminReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Snapshot not implemented")
+}
+func (UnimplementedAdminServer) Dump(context.Context, *AdminRequest) (*BookDump, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Dump not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_Halt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Halt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Admin/Halt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Halt(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Admin/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Resume(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_CancelAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CancelAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Admin/CancelAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CancelAll(ctx, req.(*CancelAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Admin/SetSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetSettings(ctx, req.(*SettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_Snapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Snapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Admin/Snapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Snapshot(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_Dump_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Dump(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Admin/Dump",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Dump(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Halt",
+			Handler:    _Admin_Halt_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _Admin_Resume_Handler,
+		},
+		{
+			MethodName: "CancelAll",
+			Handler:    _Admin_CancelAll_Handler,
+		},
+		{
+			MethodName: "SetSettings",
+			Handler:    _Admin_SetSettings_Handler,
+		},
+		{
+			MethodName: "Snapshot",
+			Handler:    _Admin_Snapshot_Handler,
+		},
+		{
+			MethodName: "Dump",
+			Handler:    _Admin_Dump_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "mytrader.proto",
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"mytrader.github.com/orderbook"
+	"mytrader.github.com/service/protoc"
+)
+
+// AdminServer is the Admin service of the server, all of its calls are restricted to the admin role by the auth
+// interceptors
+type AdminServer struct {
+	s *Server
+	protoc.UnimplementedAdminServer
+}
+
+// Admin returns the Admin service of the server
+func (s *Server) Admin() *AdminServer {
+	return &AdminServer{s: s}
+}
+
+// adminMethod checks if the full method is the call of the Admin service
+func adminMethod(method string) bool {
+	return strings.HasPrefix(method, "/"+protoc.Admin_ServiceDesc.ServiceName+"/")
+}
+
+// symbolBook is the orderbook of the symbol
+type symbolBook struct {
+	symbol string
+	ob     *orderbook.OrderBook
+}
+
+// books returns the orderbook of the symbol, or all the orderbooks if the symbol is empty
+func (a *AdminServer) books(symbol string) ([]symbolBook, error) {
+	if len(symbol) > 0 {
+		ob, err := a.s.orderBook(symbol)
+		if err != nil {
+			return nil, err
+		}
+		return []symbolBook{{symbol, ob}}, nil
+	}
+
+	var books []symbolBook
+	for _, symbol := range a.s.ex.Symbols() {
+		if ob, err := a.s.ex.OrderBook(symbol); err == nil {
+			books = append(books, symbolBook{symbol, ob})
+		}
+	}
+	return books, nil
+}
+
+// each applies the fn to the orderbook of the symbol or all the orderbooks, and returns the states of them after it
+func (a *AdminServer) each(symbol string, fn func(b symbolBook, state *protoc.BookState) error) (*protoc.AdminReply, error) {
+	books, err := a.books(symbol)
+	if err != nil {
+		return nil, err
+	}
+	reply := &protoc.AdminReply{Books: make([]*protoc.BookState, 0, len(books))}
+	for _, b := range books {
+		state := &protoc.BookState{Symbol: b.symbol}
+		if err := fn(b, state); err != nil {
+			return nil, statusError(err)
+		}
+		reply.Books = append(reply.Books, newBookState(b, state))
+	}
+	return reply, nil
+}
+
+// newBookState fills the state with the settings of the orderbook
+func newBookState(b symbolBook, state *protoc.BookState) *protoc.BookState {
+	settings := b.ob.Settings()
+	state.Halted = b.ob.Halted()
+	state.MaxQueueSize = int32(settings.MaxQueueSize)
+	state.OrderExpiration = int64(settings.OrderExpiration / time.Second)
+	return state
+}
+
+// Halt halts the trading, the new orders are rejected but the resting orders can be canceled
+func (a *AdminServer) Halt(ctx context.Context, req *protoc.AdminRequest) (*protoc.AdminReply, error) {
+	return a.each(req.Symbol, func(b symbolBook, _ *protoc.BookState) error {
+		b.ob.Halt()
+		return nil
+	})
+}
+
+// Resume resumes the trading
+func (a *AdminServer) Resume(ctx context.Context, req *protoc.AdminRequest) (*protoc.AdminReply, error) {
+	return a.each(req.Symbol, func(b symbolBook, _ *protoc.BookState) error {
+		b.ob.Resume()
+		return nil
+	})
+}
+
+// CancelAll cancels the resting and stop orders of the side and the account
+func (a *AdminServer) CancelAll(ctx context.Context, req *protoc.CancelAllRequest) (*protoc.AdminReply, error) {
+	filter := orderbook.CancelFilter{Account: req.Account}
+	if len(req.Side) > 0 {
+		var side orderbook.Side
+		switch req.Side {
+		case orderbook.Buy.String():
+			side = orderbook.Buy
+		case orderbook.Sell.String():
+			side = orderbook.Sell
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown side %q", req.Side)
+		}
+		filter.Side = &side
+	}
+
+	return a.each(req.Symbol, func(b symbolBook, state *protoc.BookState) error {
+		n, err := b.ob.CancelAll(filter)
+		state.Canceled = int32(n)
+		return err
+	})
+}
+
+// SetSettings changes the max. size of the queue and the expiration of the orders at runtime
+func (a *AdminServer) SetSettings(ctx context.Context, req *protoc.SettingsRequest) (*protoc.AdminReply, error) {
+	return a.each(req.Symbol, func(b symbolBook, _ *protoc.BookState) error {
+		if req.MaxQueueSize != 0 {
+			if err := b.ob.SetMaxQueueSize(int(req.MaxQueueSize)); err != nil {
+				return err
+			}
+		}
+		if req.OrderExpiration != 0 {
+			return b.ob.SetOrderExpiration(time.Duration(req.OrderExpiration) * time.Second)
+		}
+		return nil
+	})
+}
+
+// Snapshot writes the snapshot of the orderbook and truncates its journal
+func (a *AdminServer) Snapshot(ctx context.Context, req *protoc.AdminRequest) (*protoc.AdminReply, error) {
+	return a.each(req.Symbol, func(b symbolBook, _ *protoc.BookState) error {
+		return b.ob.SaveSnapshot()
+	})
+}
+
+// Dump returns the consistent copy of the orders and the state of the orderbook, it does not block the matching
+func (a *AdminServer) Dump(ctx context.Context, req *protoc.AdminRequest) (*protoc.BookDump, error) {
+	ob, err := a.s.orderBook(req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	d := ob.Dump()
+	inst := ob.Instrument()
+	orders := func(orders orderbook.Orders, ostatus orderbook.OrderStatus) []*protoc.OrderReply {
+		replies := make([]*protoc.OrderReply, 0, len(orders))
+		for _, o := range orders {
+			s := ostatus
+			if o.FilledQty > 0 {
+				s = orderbook.StatusPartiallyFilled
+			}
+			replies = append(replies, newOrderReply(req.Symbol, inst, o, s))
+		}
+		return replies
+	}
+	return &protoc.BookDump{
+		State: &protoc.BookState{
+			Symbol:          req.Symbol,
+			Halted:          d.Halted,
+			MaxQueueSize:    int32(d.Settings.MaxQueueSize),
+			OrderExpiration: int64(d.Settings.OrderExpiration / time.Second),
+		},
+		LastPrice: int64(d.LastPrice),
+		Bids:      orders(d.Bids, orderbook.StatusPending),
+		Asks:      orders(d.Asks, orderbook.StatusPending),
+		Stops:     orders(d.Stops, orderbook.StatusUntriggered),
+
+		PriceScale:    int32(inst.PriceScale),
+		QuantityScale: int32(inst.QtyScale),
+	}, nil
+}
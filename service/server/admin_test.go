@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"mytrader.github.com/orderbook"
+	"mytrader.github.com/service/protoc"
+)
+
+func TestAdmin(t *testing.T) {
+	ex := orderbook.NewExchange()
+	if _, err := ex.AddSymbol("BTCUSD", orderbook.WithSnapshot(filepath.Join(t.TempDir(), "BTCUSD.snapshot"), 0)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ex.AddSymbol("ETHUSD"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(ex.Close)
+	dial := newTestServer(t, ex, WithCredentials(
+		Credential{Key: "alice-key", Account: "alice", Role: RoleTrader},
+		Credential{Key: "admin-key", Role: RoleAdmin},
+	))
+	alice, admin := dial(AuthDialOptions("alice-key", "")...), dial(AuthDialOptions("admin-key", "")...)
+	ctx := context.Background()
+
+	if _, err := alice.Halt(ctx, &protoc.AdminRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("the admin calls of the trader should be denied", err)
+	}
+	if _, err := newTestClient(t, ex).(testClient).Halt(ctx, &protoc.AdminRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("the admin calls should be denied without authentication", err)
+	}
+
+	for _, symbol := range []string{"BTCUSD", "ETHUSD"} {
+		for _, side := range []orderbook.Side{orderbook.Buy, orderbook.Sell} {
+			price := int64(99)
+			if side == orderbook.Sell {
+				price = 101
+			}
+			o := &protoc.Order{Symbol: symbol, Side: int32(side), PriceMode: int32(orderbook.Limit), Price: price, Quantity: 1}
+			if _, err := alice.Create(ctx, o); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// halt and resume all the symbols
+	reply, err := admin.Halt(ctx, &protoc.AdminRequest{})
+	if err != nil || len(reply.Books) != 2 || !reply.Books[0].Halted || !reply.Books[1].Halted {
+		t.Fatal("all the symbols should be halted", reply, err)
+	}
+	if reply, err := admin.Resume(ctx, &protoc.AdminRequest{Symbol: "BTCUSD"}); err != nil || len(reply.Books) != 1 || reply.Books[0].Halted {
+		t.Fatal("BTCUSD should be resumed", reply, err)
+	}
+
+	// the settings are changed at runtime
+	if _, err := admin.SetSettings(ctx, &protoc.SettingsRequest{MaxQueueSize: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Fatal("the bad queue size should be rejected", err)
+	}
+	reply, err = admin.SetSettings(ctx, &protoc.SettingsRequest{Symbol: "BTCUSD", MaxQueueSize: 1, OrderExpiration: 60})
+	if err != nil || reply.Books[0].MaxQueueSize != 1 || reply.Books[0].OrderExpiration != 60 {
+		t.Fatal("the settings should be changed", reply, err)
+	}
+	o := &protoc.Order{Symbol: "BTCUSD", Side: int32(orderbook.Buy), PriceMode: int32(orderbook.Limit), Price: 98, Quantity: 1}
+	if _, err := alice.Create(ctx, o); status.Code(err) != codes.ResourceExhausted {
+		t.Fatal("the queue should be full", err)
+	}
+
+	dump, err := admin.Dump(ctx, &protoc.AdminRequest{Symbol: "BTCUSD"})
+	if err != nil || len(dump.Bids) != 1 || len(dump.Asks) != 1 || dump.Bids[0].Account != "alice" || dump.State.MaxQueueSize != 1 {
+		t.Fatal("wrong dump of BTCUSD", dump, err)
+	}
+	if _, err := admin.Dump(ctx, &protoc.AdminRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatal("the dump should require the symbol", err)
+	}
+
+	// cancel all the orders by side and globally
+	if _, err := admin.CancelAll(ctx, &protoc.CancelAllRequest{Side: "long"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatal("the unknown side should be rejected", err)
+	}
+	reply, err = admin.CancelAll(ctx, &protoc.CancelAllRequest{Symbol: "BTCUSD", Side: "sell"})
+	if err != nil || reply.Books[0].Canceled != 1 {
+		t.Fatal("the sell order should be canceled", reply, err)
+	}
+	reply, err = admin.CancelAll(ctx, &protoc.CancelAllRequest{Account: "alice"})
+	if err != nil || reply.Books[0].Canceled != 1 || reply.Books[1].Canceled != 2 {
+		t.Fatal("the rest of the orders of alice should be canceled", reply, err)
+	}
+
+	// the snapshot is forced
+	if _, err := admin.Snapshot(ctx, &protoc.AdminRequest{Symbol: "BTCUSD"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := admin.Snapshot(ctx, &protoc.AdminRequest{Symbol: "ETHUSD"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatal("the snapshot of ETHUSD is disabled", err)
+	}
+}
@@ -0,0 +1,511 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// APIKeyHeader is the key of the metadata of the api key
+	APIKeyHeader = "x-api-key"
+	// TimestampHeader is the key of the metadata of the unix timestamp of the signed request
+	TimestampHeader = "x-timestamp"
+	// SignatureHeader is the key of the metadata of the hex HMAC-SHA256 signature of the signed request
+	SignatureHeader = "x-signature"
+	// NonceHeader is the key of the metadata of the unique nonce of the signed request, the nonce of an api key can
+	// only be used once
+	NonceHeader = "x-nonce"
+	// maxClockSkew is the max. difference between the timestamp of the signed request and the clock of the server
+	maxClockSkew = 30 * time.Second
+	// maxNonceLen is the max. length of the nonce
+	maxNonceLen = 64
+)
+
+// Role is the role of the client which is authenticated by its credential
+type Role string
+
+const (
+	// RoleTrader can only act on the orders, positions and balances of its own account
+	RoleTrader Role = "trader"
+	// RoleAdmin can act on all the accounts and call the admin calls
+	RoleAdmin Role = "admin"
+)
+
+// Identity is the client of the call which is put into the context by the auth interceptor
+type Identity struct {
+	Account string
+	Role    Role
+}
+
+// Credential is the api key of a client, the requests of the key with a secret should be signed by HMAC-SHA256 of
+// the secret, the key without a secret is a bearer key
+type Credential struct {
+	Key     string `json:"key"`
+	Secret  string `json:"secret"`
+	Account string `json:"account"`
+	Role    Role   `json:"role"`
+}
+
+// identityKey is the key of the identity in the context
+type identityKey struct{}
+
+// IdentityFromContext returns the identity of the call, false if the call is not authenticated
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// WithCredentials is an option for the api keys of the clients, all the calls should be authenticated by one of
+// them. The calls are not authenticated if there is no credential.
+func WithCredentials(creds ...Credential) Option {
+	return func(s *Server) error {
+		if s.credentials == nil {
+			s.credentials = make(map[string]Credential)
+		}
+		for _, c := range creds {
+			if len(c.Key) == 0 {
+				return errors.New("the api key is empty")
+			}
+			if c.Role != RoleTrader && c.Role != RoleAdmin {
+				return fmt.Errorf("unknown role %q of the api key %s", c.Role, c.Key)
+			}
+			if c.Role == RoleTrader && len(c.Account) == 0 {
+				return fmt.Errorf("the account of the api key %s is empty", c.Key)
+			}
+			s.credentials[c.Key] = c
+		}
+		return nil
+	}
+}
+
+// WithTLS is an option for the certificate and key of the server, the server accepts TLS connections only
+func WithTLS(certFile, keyFile string) Option {
+	return func(s *Server) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		if s.tls == nil {
+			s.tls = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		s.tls.Certificates = []tls.Certificate{cert}
+		return nil
+	}
+}
+
+// WithClientCA is an option for the CA of the client certificates, the clients should have the certificates which
+// are signed by it (mTLS). It requires WithTLS.
+func WithClientCA(caFile string) Option {
+	return func(s *Server) error {
+		pool, err := loadCertPool(caFile)
+		if err != nil {
+			return err
+		}
+		if s.tls == nil {
+			s.tls = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		s.tls.ClientCAs = pool
+		s.tls.ClientAuth = tls.RequireAndVerifyClientCert
+		return nil
+	}
+}
+
+// loadCertPool returns the pool of the certificates in the pem file
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	b, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("no certificate in %s", caFile)
+	}
+	return pool, nil
+}
+
+// serverOptions returns the options of the gRPC server with the transport credentials and the auth interceptors
+func (s *Server) serverOptions() []grpc.ServerOption {
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(s.unaryAuth),
+		grpc.StreamInterceptor(s.streamAuth),
+	}
+	if s.tls != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.tls)))
+	}
+	return opts
+}
+
+// unaryAuth authenticates the unary call and puts the identity into its context, the calls of the Admin service
+// are restricted to the admin role
+func (s *Server) unaryAuth(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if len(s.credentials) > 0 {
+		msg, _ := req.(proto.Message)
+		id, err := s.authenticate(ctx, info.FullMethod, msg)
+		if err != nil {
+			return nil, err
+		}
+		ctx = context.WithValue(ctx, identityKey{}, id)
+	}
+	if adminMethod(info.FullMethod) {
+		if err := admin(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return handler(ctx, req)
+}
+
+// authStream is the server stream with the context of the identity, the identity of the signed stream is put into
+// the context after the signature of its first request is verified
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	// id is the identity of the stream and verify verifies the signature of the first request, it is nil after that
+	id     Identity
+	verify func(req proto.Message) error
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+// RecvMsg receives the request and verifies the signature of the first one
+func (s *authStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil || s.verify == nil {
+		return err
+	}
+	verify := s.verify
+	s.verify = nil
+	msg, _ := m.(proto.Message)
+	if err := verify(msg); err != nil {
+		return err
+	}
+	s.ctx = context.WithValue(s.ctx, identityKey{}, s.id)
+	return nil
+}
+
+// streamAuth authenticates the stream and puts the identity into its context, the signature of the stream covers
+// its first request which is received by the handler of the server stream before it calls the service
+func (s *Server) streamAuth(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if len(s.credentials) == 0 {
+		return handler(srv, ss)
+	}
+	c, call, err := s.credential(ss.Context())
+	if err != nil {
+		return err
+	}
+	id := Identity{Account: c.Account, Role: c.Role}
+	if call == nil {
+		return handler(srv, &authStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), identityKey{}, id)})
+	}
+	return handler(srv, &authStream{
+		ServerStream: ss,
+		ctx:          ss.Context(),
+		id:           id,
+		verify: func(req proto.Message) error {
+			return s.verify(c, call, info.FullMethod, req)
+		},
+	})
+}
+
+// signedCall is the timestamp, nonce and signature of the signed call
+type signedCall struct {
+	timestamp int64
+	nonce     string
+	signature string
+}
+
+// authenticate returns the identity of the api key in the metadata, the signature, timestamp and nonce are verified
+// if the key has a secret
+func (s *Server) authenticate(ctx context.Context, method string, req proto.Message) (Identity, error) {
+	c, call, err := s.credential(ctx)
+	if err != nil {
+		return Identity{}, err
+	}
+	if call != nil {
+		if err := s.verify(c, call, method, req); err != nil {
+			return Identity{}, err
+		}
+	}
+	return Identity{Account: c.Account, Role: c.Role}, nil
+}
+
+// credential returns the credential of the api key in the metadata and the signed call if the key has a secret,
+// the timestamp of the call should be in the window of the clock of the server
+func (s *Server) credential(ctx context.Context) (Credential, *signedCall, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get(APIKeyHeader)
+	if len(keys) == 0 {
+		return Credential{}, nil, status.Errorf(codes.Unauthenticated, "the api key is required")
+	}
+	c, exist := s.credentials[keys[0]]
+	if !exist {
+		return Credential{}, nil, status.Errorf(codes.Unauthenticated, "unknown api key")
+	}
+	if len(c.Secret) == 0 {
+		return c, nil, nil
+	}
+
+	timestamps, nonces, signatures := md.Get(TimestampHeader), md.Get(NonceHeader), md.Get(SignatureHeader)
+	if len(timestamps) == 0 || len(nonces) == 0 || len(signatures) == 0 {
+		return Credential{}, nil, status.Errorf(codes.Unauthenticated, "the request should be signed")
+	}
+	if len(nonces[0]) == 0 || len(nonces[0]) > maxNonceLen {
+		return Credential{}, nil, status.Errorf(codes.Unauthenticated, "the nonce should have 1 to %d characters", maxNonceLen)
+	}
+	ts, err := strconv.ParseInt(timestamps[0], 10, 64)
+	if err != nil {
+		return Credential{}, nil, status.Errorf(codes.Unauthenticated, "bad timestamp")
+	}
+	if skew := time.Since(time.Unix(ts, 0)); skew > maxClockSkew || skew < -maxClockSkew {
+		return Credential{}, nil, status.Errorf(codes.Unauthenticated, "the timestamp is out of %v", maxClockSkew)
+	}
+	return c, &signedCall{timestamp: ts, nonce: nonces[0], signature: signatures[0]}, nil
+}
+
+// verify checks the signature of the call of the request, and the nonce of the valid signature is used up
+func (s *Server) verify(c Credential, call *signedCall, method string, req proto.Message) error {
+	expected, err := Sign(c.Secret, method, call.timestamp, call.nonce, req)
+	if err != nil {
+		return status.Errorf(codes.Internal, err.Error())
+	}
+	if !hmac.Equal([]byte(call.signature), []byte(expected)) {
+		return status.Errorf(codes.Unauthenticated, "bad signature")
+	}
+	// the timestamp is out of the window after it expires, so the nonce is kept until then
+	if !s.nonces.use(c.Key, call.nonce, time.Unix(call.timestamp, 0).Add(maxClockSkew), time.Now()) {
+		return status.Errorf(codes.Unauthenticated, "the nonce is used")
+	}
+	return nil
+}
+
+// nonceCache is the nonces of the signed calls which are seen in the window of their timestamps
+type nonceCache struct {
+	sync.Mutex
+	// expires are the expiration of the nonces by the api key and the nonce
+	expires map[string]time.Time
+	// pruned is the last time when the expired nonces are removed
+	pruned time.Time
+}
+
+// newNonceCache returns an empty cache of the nonces
+func newNonceCache() *nonceCache {
+	return &nonceCache{expires: make(map[string]time.Time)}
+}
+
+// use records the nonce of the api key until it expires, it returns false if the nonce is used. The expired nonces
+// are removed every maxClockSkew.
+func (c *nonceCache) use(key, nonce string, expire, now time.Time) bool {
+	c.Lock()
+	defer c.Unlock()
+	if now.Sub(c.pruned) > maxClockSkew {
+		for k, e := range c.expires {
+			if e.Before(now) {
+				delete(c.expires, k)
+			}
+		}
+		c.pruned = now
+	}
+
+	k := key + "\n" + nonce
+	if e, exist := c.expires[k]; exist && !e.Before(now) {
+		return false
+	}
+	c.expires[k] = expire
+	return true
+}
+
+// Sign returns the hex HMAC-SHA256 signature of the call by the secret, the signed payload is the full method, the
+// unix timestamp, the nonce and the sha256 of the deterministic encoding of the request separated by "\n". The
+// request of the stream is its first request.
+func Sign(secret, method string, timestamp int64, nonce string, req proto.Message) (string, error) {
+	var body []byte
+	if req != nil {
+		var err error
+		if body, err = (proto.MarshalOptions{Deterministic: true}).Marshal(req); err != nil {
+			return "", err
+		}
+	}
+	digest := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s\n%d\n%s\n%s", method, timestamp, nonce, hex.EncodeToString(digest[:]))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// newNonce returns the random hex nonce of the signed call
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// signedStream is the client stream which is created by its first request, so the request is signed by the
+// metadata of the stream
+type signedStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	create func(req proto.Message) (grpc.ClientStream, error)
+}
+
+// SendMsg creates the stream by the first request and sends the request
+func (s *signedStream) SendMsg(m interface{}) error {
+	if s.ClientStream == nil {
+		msg, _ := m.(proto.Message)
+		cs, err := s.create(msg)
+		if err != nil {
+			return err
+		}
+		s.ClientStream = cs
+	}
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *signedStream) Context() context.Context {
+	if s.ClientStream == nil {
+		return s.ctx
+	}
+	return s.ClientStream.Context()
+}
+
+// errStreamNotCreated is the error of the signed stream which is used before its first request
+var errStreamNotCreated = status.Errorf(codes.Internal, "the signed stream is created by its first request")
+
+func (s *signedStream) Header() (metadata.MD, error) {
+	if s.ClientStream == nil {
+		return nil, errStreamNotCreated
+	}
+	return s.ClientStream.Header()
+}
+
+func (s *signedStream) Trailer() metadata.MD {
+	if s.ClientStream == nil {
+		return nil
+	}
+	return s.ClientStream.Trailer()
+}
+
+func (s *signedStream) CloseSend() error {
+	if s.ClientStream == nil {
+		return errStreamNotCreated
+	}
+	return s.ClientStream.CloseSend()
+}
+
+func (s *signedStream) RecvMsg(m interface{}) error {
+	if s.ClientStream == nil {
+		return errStreamNotCreated
+	}
+	return s.ClientStream.RecvMsg(m)
+}
+
+// AuthDialOptions returns the dial options of the client which send the api key with the calls, the calls are
+// signed if the secret is not empty and the streams are signed with their first requests
+func AuthDialOptions(key, secret string) []grpc.DialOption {
+	outgoing := func(ctx context.Context, method string, req proto.Message) (context.Context, error) {
+		ctx = metadata.AppendToOutgoingContext(ctx, APIKeyHeader, key)
+		if len(secret) == 0 {
+			return ctx, nil
+		}
+		ts := time.Now().Unix()
+		nonce, err := newNonce()
+		if err != nil {
+			return nil, err
+		}
+		sig, err := Sign(secret, method, ts, nonce, req)
+		if err != nil {
+			return nil, err
+		}
+		return metadata.AppendToOutgoingContext(ctx, TimestampHeader, strconv.FormatInt(ts, 10), NonceHeader, nonce, SignatureHeader, sig), nil
+	}
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			msg, _ := req.(proto.Message)
+			ctx, err := outgoing(ctx, method, msg)
+			if err != nil {
+				return err
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			create := func(req proto.Message) (grpc.ClientStream, error) {
+				ctx, err := outgoing(ctx, method, req)
+				if err != nil {
+					return nil, err
+				}
+				return streamer(ctx, desc, cc, method, opts...)
+			}
+			if len(secret) == 0 {
+				return create(nil)
+			}
+			return &signedStream{ctx: ctx, create: create}, nil
+		}),
+	}
+}
+
+// ClientTLS returns the transport credentials of the client which verifies the server by the CA, the client
+// certificate is sent for mTLS if its cert and key files are not empty
+func ClientTLS(caFile, certFile, keyFile, serverName string) (credentials.TransportCredentials, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: serverName}
+	if len(caFile) > 0 {
+		pool, err := loadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+	if len(certFile) > 0 || len(keyFile) > 0 {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// authorize checks if the client of the call can act on the account, the admin can act on all the accounts and
+// all the calls are authorized if they are not authenticated
+func authorize(ctx context.Context, account string) error {
+	id, ok := IdentityFromContext(ctx)
+	if !ok || id.Role == RoleAdmin || id.Account == account {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "the client can only act on the account %q", id.Account)
+}
+
+// ownAccount returns the account of the request which is authorized for the client of the call, the account of
+// the trader is used if the request has no account
+func ownAccount(ctx context.Context, account string) (string, error) {
+	if id, ok := IdentityFromContext(ctx); ok && id.Role == RoleTrader && len(account) == 0 {
+		return id.Account, nil
+	}
+	return account, authorize(ctx, account)
+}
+
+// admin checks if the client of the call is the admin, the admin calls are denied if the calls are not
+// authenticated
+func admin(ctx context.Context) error {
+	if id, ok := IdentityFromContext(ctx); ok && id.Role == RoleAdmin {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "admin only")
+}
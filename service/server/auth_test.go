@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"mytrader.github.com/orderbook"
+	"mytrader.github.com/service/protoc"
+)
+
+func TestAuth(t *testing.T) {
+	ex := newTestExchange(t, "BTCUSD")
+	dial := newTestServer(t, ex, WithCredentials(
+		Credential{Key: "alice-key", Secret: "alice-secret", Account: "alice", Role: RoleTrader},
+		Credential{Key: "bob-key", Account: "bob", Role: RoleTrader},
+		Credential{Key: "admin-key", Secret: "admin-secret", Role: RoleAdmin},
+	))
+	ctx := context.Background()
+	order := func(account string) *protoc.Order {
+		return &protoc.Order{Symbol: "BTCUSD", Account: account, Side: int32(orderbook.Buy), PriceMode: int32(orderbook.Limit), Price: 100, Quantity: 1}
+	}
+
+	if _, err := dial().Create(ctx, order("alice")); status.Code(err) != codes.Unauthenticated {
+		t.Fatal("the call without the api key should be unauthenticated", err)
+	}
+	if _, err := dial(AuthDialOptions("alice-key", "")...).Create(ctx, order("alice")); status.Code(err) != codes.Unauthenticated {
+		t.Fatal("the call of the key with a secret should be signed", err)
+	}
+	if _, err := dial(AuthDialOptions("alice-key", "bad-secret")...).Create(ctx, order("alice")); status.Code(err) != codes.Unauthenticated {
+		t.Fatal("the call with the bad signature should be unauthenticated", err)
+	}
+
+	// the signature covers the timestamp and the nonce, the stale one and the reused nonce are rejected
+	signed := func(method string, ts int64, nonce string, req proto.Message) context.Context {
+		t.Helper()
+		sig, err := Sign("alice-secret", method, ts, nonce, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return metadata.AppendToOutgoingContext(ctx, APIKeyHeader, "alice-key", TimestampHeader, strconv.FormatInt(ts, 10), NonceHeader, nonce, SignatureHeader, sig)
+	}
+	stale := signed("/Trader/Create", time.Now().Add(-time.Minute).Unix(), "n1", order("alice"))
+	if _, err := dial().Create(stale, order("alice")); status.Code(err) != codes.Unauthenticated {
+		t.Fatal("the stale signature should be unauthenticated", err)
+	}
+	noNonce := metadata.AppendToOutgoingContext(ctx, APIKeyHeader, "alice-key", TimestampHeader, strconv.FormatInt(time.Now().Unix(), 10), SignatureHeader, "00")
+	if _, err := dial().Create(noNonce, order("alice")); status.Code(err) != codes.Unauthenticated {
+		t.Fatal("the call without the nonce should be unauthenticated", err)
+	}
+	replayed := signed("/Trader/Create", time.Now().Unix(), "n2", order("alice"))
+	if _, err := dial().Create(replayed, order("alice")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dial().Create(replayed, order("alice")); status.Code(err) != codes.Unauthenticated {
+		t.Fatal("the replayed call should be unauthenticated", err)
+	}
+
+	// the signature of the stream covers its first request
+	req := &protoc.ExecutionRequest{Symbol: "BTCUSD", Account: "alice"}
+	tampered := signed("/Trader/SubscribeExecutions", time.Now().Unix(), "n3", req)
+	stream, err := dial().SubscribeExecutions(tampered, &protoc.ExecutionRequest{Symbol: "BTCUSD", Account: "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Fatal("the stream with the other request should be unauthenticated", err)
+	}
+
+	alice, bob, admin := dial(AuthDialOptions("alice-key", "alice-secret")...), dial(AuthDialOptions("bob-key", "")...), dial(AuthDialOptions("admin-key", "admin-secret")...)
+	reply, err := alice.Create(ctx, order(""))
+	if err != nil || reply.Account != "alice" {
+		t.Fatal("the order should be owned by the account of the client", reply, err)
+	}
+	if _, err := bob.Create(ctx, order("alice")); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("the client should not create the orders of the other accounts", err)
+	}
+
+	// the clients can only act on their own orders
+	if _, err := bob.Get(ctx, &protoc.GetOrder{Symbol: "BTCUSD", Id: reply.ID}); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("the client should not get the orders of the other accounts", err)
+	}
+	if _, err := bob.Amend(ctx, &protoc.AmendOrder{Symbol: "BTCUSD", Id: reply.ID, Price: 100, Quantity: 2}); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("the client should not amend the orders of the other accounts", err)
+	}
+	if _, err := bob.Cancel(ctx, &protoc.CancelOrder{Symbol: "BTCUSD", Id: reply.ID}); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("the client should not cancel the orders of the other accounts", err)
+	}
+	if _, err := bob.GetPosition(ctx, &protoc.PositionRequest{Symbol: "BTCUSD", Account: "alice"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("the client should not get the positions of the other accounts", err)
+	}
+	if _, err := alice.Get(ctx, &protoc.GetOrder{Symbol: "BTCUSD", Id: reply.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if r, err := admin.Cancel(ctx, &protoc.CancelOrder{Symbol: "BTCUSD", Id: reply.ID}); err != nil || r.Status != orderbook.StatusCanceled.String() {
+		t.Fatal("the admin should cancel the orders of all the accounts", r, err)
+	}
+
+	// the streams are authenticated too
+	stream, err = bob.SubscribeExecutions(ctx, &protoc.ExecutionRequest{Symbol: "BTCUSD", Account: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("the client should not subscribe the executions of the other accounts", err)
+	}
+	// the identity of the signed stream is verified by its first request
+	if stream, err = alice.SubscribeExecutions(ctx, &protoc.ExecutionRequest{Symbol: "BTCUSD", Account: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("the signed client should not subscribe the executions of the other accounts", err)
+	}
+	md, err := alice.SubscribeMarketData(ctx, &protoc.MarketDataRequest{Symbol: "BTCUSD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := md.Recv(); err != nil {
+		t.Fatal("the signed stream should receive the snapshot", err)
+	}
+}
+
+func TestTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := newTestCert(t, dir, "ca", nil, nil)
+	newTestCert(t, dir, "server", ca, caKey)
+	newTestCert(t, dir, "client", ca, caKey)
+	file := func(name string) string { return filepath.Join(dir, name) }
+
+	if _, err := New(WithClientCA(file("ca.pem"))); err == nil {
+		t.Fatal("the client CA should require the certificate of the server")
+	}
+	dial := newTestServer(t, newTestExchange(t, "BTCUSD"), WithTLS(file("server.pem"), file("server.key")), WithClientCA(file("ca.pem")))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req := &protoc.InstrumentRequest{Symbol: "BTCUSD"}
+
+	creds, err := ClientTLS(file("ca.pem"), "", "", "localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dial(grpc.WithTransportCredentials(creds)).GetInstrument(ctx, req); status.Code(err) != codes.Unavailable {
+		t.Fatal("the client without the certificate should be rejected", err)
+	}
+	if creds, err = ClientTLS(file("ca.pem"), file("client.pem"), file("client.key"), "localhost"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dial(grpc.WithTransportCredentials(creds)).GetInstrument(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestCert writes the certificate and key of the name to the dir, the certificate is self-signed if the parent
+// is nil
+func newTestCert(tb testing.TB, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	tb.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		tmpl.IsCA, tmpl.BasicConstraintsValid = true, true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	for file, block := range map[string]*pem.Block{
+		name + ".pem": {Type: "CERTIFICATE", Bytes: der},
+		name + ".key": {Type: "EC PRIVATE KEY", Bytes: keyDER},
+	} {
+		if err := os.WriteFile(filepath.Join(dir, file), pem.EncodeToMemory(block), 0o600); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return cert, key
+}
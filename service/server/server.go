@@ -2,13 +2,17 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -18,6 +22,13 @@ import (
 
 type Option func(s *Server) error
 
+const (
+	// marketDataBufferSize is the size of the buffer of each market data subscriber
+	marketDataBufferSize = 1024
+	// executionBufferSize is the size of the buffer of each execution report subscriber
+	executionBufferSize = 1024
+)
+
 type serveErr string
 
 func (s serveErr) String() string {
@@ -42,21 +53,35 @@ func WithAddr(addr string) Option {
 
 // TODO: create an orderbook interface to decuple the server & orderbook
 // for now, just use the mytrader.github.com/orderbook
-func WithOrderBook(ob *orderbook.OrderBook) Option {
+func WithExchange(ex *orderbook.Exchange) Option {
+	return func(s *Server) error {
+
+		if ex == nil {
+			return errors.New("the exchange is empty")
+		}
+
+		s.ex = ex
+		return nil
+	}
+}
+
+// WithLedger is an option for the ledger of the accounts which are deposited, withdrawn and queried by the server
+func WithLedger(l *orderbook.Ledger) Option {
 	return func(s *Server) error {
 
-		if ob == nil {
-			return errors.New("the orderbook is empty")
+		if l == nil {
+			return errors.New("the ledger is empty")
 		}
 
-		s.ob = ob
+		s.ledger = l
 		return nil
 	}
 }
 
 func New(opts ...Option) (*Server, error) {
 	s := &Server{
-		addr: "localhost:9999",
+		addr:   "localhost:9999",
+		nonces: newNonceCache(),
 	}
 
 	for _, opt := range opts {
@@ -65,63 +90,390 @@ func New(opts ...Option) (*Server, error) {
 		}
 	}
 
+	if s.tls != nil && len(s.tls.Certificates) == 0 {
+		return nil, errors.New("the client CA requires the certificate of the server")
+	}
 	return s, nil
 }
 
 type Server struct {
-	addr string
-	ob   *orderbook.OrderBook
+	addr   string
+	ex     *orderbook.Exchange
+	ledger *orderbook.Ledger
+	// credentials are the api keys of the clients, tls is the config of the TLS connections
+	credentials map[string]Credential
+	tls         *tls.Config
+	// nonces are the nonces of the signed calls in the window of their timestamps
+	nonces *nonceCache
 	protoc.UnimplementedTraderServer
 }
 
+// orderBook returns the orderbook of the symbol
+func (s *Server) orderBook(symbol string) (*orderbook.OrderBook, error) {
+	if len(symbol) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, orderbook.ErrBadSymbol.Error())
+	}
+	ob, err := s.ex.OrderBook(symbol)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return ob, nil
+}
+
 func (s *Server) Create(ctx context.Context, order *protoc.Order) (*protoc.OrderReply, error) {
+	ob, err := s.orderBook(order.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	account, err := ownAccount(ctx, order.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	inst := ob.Instrument()
+	price, qty, err := priceQty(inst, order.Price, order.Quantity, order.DecimalPrice, order.DecimalQuantity)
+	if err != nil {
+		return nil, statusError(err)
+	}
 
 	var side orderbook.Side = orderbook.Side(order.Side)
-	reply := &protoc.OrderReply{
-		Price: order.Price,
+	opts := []orderbook.OrderOption{
+		orderbook.WithTimeInForce(orderbook.TimeInForce(order.TimeInForce)),
+		orderbook.WithAccount(account),
+	}
+	if order.ExpireTime > 0 {
+		opts = append(opts, orderbook.WithExpireTime(time.Unix(order.ExpireTime, 0)))
+	}
+	stopPrice := int(order.StopPrice)
+	if len(order.DecimalStopPrice) > 0 {
+		if stopPrice, err = inst.ParsePrice(order.DecimalStopPrice); err != nil {
+			return nil, statusError(err)
+		}
+	}
+	if stopPrice != 0 {
+		opts = append(opts, orderbook.WithStopPrice(stopPrice))
+	}
+	displayQty := int(order.DisplayQuantity)
+	if len(order.DecimalDisplayQuantity) > 0 {
+		if displayQty, err = inst.ParseQty(order.DecimalDisplayQuantity); err != nil {
+			return nil, statusError(err)
+		}
+	}
+	if displayQty != 0 {
+		opts = append(opts, orderbook.WithDisplayQty(displayQty))
+	}
+	protectionPrice := int(order.ProtectionPrice)
+	if len(order.DecimalProtectionPrice) > 0 {
+		if protectionPrice, err = inst.ParsePrice(order.DecimalProtectionPrice); err != nil {
+			return nil, statusError(err)
+		}
+	}
+	if protectionPrice != 0 {
+		opts = append(opts, orderbook.WithProtectionPrice(protectionPrice))
+	}
+	if order.MaxSlippage != 0 {
+		opts = append(opts, orderbook.WithMaxSlippage(int(order.MaxSlippage)))
+	}
+	if order.PostOnly != 0 {
+		opts = append(opts, orderbook.WithPostOnly(orderbook.PostOnly(order.PostOnly)))
+	}
+	if order.ReduceOnly {
+		opts = append(opts, orderbook.WithReduceOnly())
+	}
+	if len(order.Stp) > 0 {
+		mode, err := orderbook.ParseSTP(order.Stp)
+		if err != nil {
+			return nil, statusError(err)
+		}
+		opts = append(opts, orderbook.WithSTP(mode))
 	}
 
+	var id string
 	switch orderbook.PriceMode(order.PriceMode) {
 	case orderbook.Limit:
-		lid, err := s.ob.ProcessLimitOrder(side, int(order.Price), int(order.Quantity))
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, err.Error())
-		}
-		reply.ID = lid
+		id, err = ob.ProcessLimitOrder(side, price, qty, opts...)
 	case orderbook.Market:
-		mid, err := s.ob.ProcessMarketOrder(side, int(order.Quantity))
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, err.Error())
-		}
-		reply.ID = mid
+		id, err = ob.ProcessMarketOrder(side, qty, opts...)
 	default:
 		return nil, status.Errorf(codes.InvalidArgument, "unknown price mode")
 	}
-
-	s.ob.Info()
+	if err != nil {
+		return nil, statusError(err)
+	}
 
 	var o orderbook.Order
-	ostatus, err := s.ob.GetOrder(reply.ID, &o)
+	ostatus, err := ob.GetOrder(id, &o)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
+	return newOrderReply(order.Symbol, inst, &o, ostatus), nil
+}
 
-	reply.PriceMode = o.PriceMode.String()
-	reply.Status = ostatus.String()
-	reply.Side = o.Side.String()
-	reply.Quantity = int64(o.Qty)
-	reply.Timestamp = o.Time.Unix()
-	return reply, nil
-
+// priceQty returns the price and qty of the request in the smallest units of the instrument, the decimal ones
+// are used if they are set
+func priceQty(inst orderbook.Instrument, price, qty int64, decimalPrice, decimalQty string) (int, int, error) {
+	p, q := int(price), int(qty)
+	var err error
+	if len(decimalPrice) > 0 {
+		if p, err = inst.ParsePrice(decimalPrice); err != nil {
+			return 0, 0, err
+		}
+	}
+	if len(decimalQty) > 0 {
+		if q, err = inst.ParseQty(decimalQty); err != nil {
+			return 0, 0, err
+		}
+	}
+	return p, q, nil
 }
 
 func (s *Server) Get(ctx context.Context, order *protoc.GetOrder) (*protoc.OrderReply, error) {
+	ob, err := s.orderBook(order.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var o orderbook.Order
+	ostatus, err := ob.GetOrder(order.Id, &o)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if err := authorize(ctx, o.Account); err != nil {
+		return nil, err
+	}
+	return newOrderReply(order.Symbol, ob.Instrument(), &o, ostatus), nil
+}
+
+func (s *Server) Cancel(ctx context.Context, order *protoc.CancelOrder) (*protoc.OrderReply, error) {
+	ob, err := s.orderBook(order.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ownOrder(ctx, ob, order.Id); err != nil {
+		return nil, err
+	}
+	if err := ob.CancelOrder(order.Id); err != nil {
+		return nil, statusError(err)
+	}
+
 	var o orderbook.Order
-	ostatus, err := s.ob.GetOrder(order.Id, &o)
+	ostatus, err := ob.GetOrder(order.Id, &o)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
-	reply := &protoc.OrderReply{
+	return newOrderReply(order.Symbol, ob.Instrument(), &o, ostatus), nil
+}
+
+func (s *Server) Amend(ctx context.Context, order *protoc.AmendOrder) (*protoc.OrderReply, error) {
+	ob, err := s.orderBook(order.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ownOrder(ctx, ob, order.Id); err != nil {
+		return nil, err
+	}
+	price, qty, err := priceQty(ob.Instrument(), order.Price, order.Quantity, order.DecimalPrice, order.DecimalQuantity)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if err := ob.AmendOrder(order.Id, price, qty); err != nil {
+		return nil, statusError(err)
+	}
+
+	var o orderbook.Order
+	ostatus, err := ob.GetOrder(order.Id, &o)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return newOrderReply(order.Symbol, ob.Instrument(), &o, ostatus), nil
+}
+
+func (s *Server) GetDepth(ctx context.Context, req *protoc.DepthRequest) (*protoc.DepthReply, error) {
+	ob, err := s.orderBook(req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	depth := ob.Depth(int(req.Levels))
+	inst := ob.Instrument()
+	return &protoc.DepthReply{
+		Symbol:        req.Symbol,
+		Bids:          newPriceLevels(depth.Bids),
+		Asks:          newPriceLevels(depth.Asks),
+		PriceScale:    int32(inst.PriceScale),
+		QuantityScale: int32(inst.QtyScale),
+	}, nil
+}
+
+func (s *Server) GetInstrument(ctx context.Context, req *protoc.InstrumentRequest) (*protoc.Instrument, error) {
+	ob, err := s.orderBook(req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	inst := ob.Instrument()
+	return &protoc.Instrument{
+		Symbol:        req.Symbol,
+		PriceScale:    int32(inst.PriceScale),
+		QuantityScale: int32(inst.QtyScale),
+		TickSize:      int64(inst.TickSize),
+		LotSize:       int64(inst.LotSize),
+		MinQuantity:   int64(inst.MinQty),
+		MaxQuantity:   int64(inst.MaxQty),
+	}, nil
+}
+
+func (s *Server) GetPosition(ctx context.Context, req *protoc.PositionRequest) (*protoc.Position, error) {
+	ob, err := s.orderBook(req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	account, err := ownAccount(ctx, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protoc.Position{
+		Symbol:        req.Symbol,
+		Account:       account,
+		Quantity:      int64(ob.GetPosition(account)),
+		QuantityScale: int32(ob.Instrument().QtyScale),
+	}, nil
+}
+
+func (s *Server) SubscribeMarketData(req *protoc.MarketDataRequest, stream protoc.Trader_SubscribeMarketDataServer) error {
+	ob, err := s.orderBook(req.Symbol)
+	if err != nil {
+		return err
+	}
+
+	snapshot, ch := ob.SubscribeMarketData(int(req.Levels), marketDataBufferSize)
+	defer ob.UnsubscribeMarketData(ch)
+
+	if err := stream.Send(newMarketData(req.Symbol, ob.Instrument(), snapshot)); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case md, ok := <-ch:
+			if !ok {
+				return status.Errorf(codes.ResourceExhausted, "the subscriber is too slow to receive the market data")
+			}
+			if err := stream.Send(newMarketData(req.Symbol, ob.Instrument(), md)); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (s *Server) SubscribeExecutions(req *protoc.ExecutionRequest, stream protoc.Trader_SubscribeExecutionsServer) error {
+	ob, err := s.orderBook(req.Symbol)
+	if err != nil {
+		return err
+	}
+
+	account, err := ownAccount(stream.Context(), req.Account)
+	if err != nil {
+		return err
+	}
+
+	ch := ob.SubscribeExecutions(orderbook.ExecutionFilter{OrderID: req.OrderId, Account: account}, executionBufferSize)
+	defer ob.UnsubscribeExecutions(ch)
+
+	for {
+		select {
+		case er, ok := <-ch:
+			if !ok {
+				return status.Errorf(codes.ResourceExhausted, "the subscriber is too slow to receive the execution reports")
+			}
+			if err := stream.Send(newExecutionReport(req.Symbol, ob.Instrument(), er)); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// newExecutionReport converts the execution report of the orderbook to the message
+func newExecutionReport(symbol string, inst orderbook.Instrument, er orderbook.ExecutionReport) *protoc.ExecutionReport {
+	r := &protoc.ExecutionReport{
+		Seq:       er.Seq,
+		Symbol:    symbol,
+		Timestamp: er.Time.Unix(),
+		Type:      er.Type.String(),
+		Order:     newOrderReply(symbol, inst, &er.Order, er.Status),
+		Reason:    er.Reason,
+	}
+	if er.Type == orderbook.ExecPartialFill || er.Type == orderbook.ExecFill {
+		r.Fill = newTradePrint(er.Fill)
+	}
+	return r
+}
+
+// newTradePrint converts the fill of the orderbook to the message
+func newTradePrint(f orderbook.Fill) *protoc.TradePrint {
+	return &protoc.TradePrint{
+		ID:            f.ID.String(),
+		Price:         int64(f.Price),
+		Quantity:      int64(f.Qty),
+		AggressorSide: f.Aggressor.String(),
+		MakerOrderID:  f.MakerOrderID.String(),
+		TakerOrderID:  f.TakerOrderID.String(),
+	}
+}
+
+// newMarketData converts the market data of the orderbook to the message
+func newMarketData(symbol string, inst orderbook.Instrument, md orderbook.MarketData) *protoc.MarketData {
+	m := &protoc.MarketData{Seq: md.Seq, Symbol: symbol, Timestamp: md.Time.Unix()}
+	switch md.Type {
+	case orderbook.MarketDataSnapshot:
+		m.Event = &protoc.MarketData_Snapshot{Snapshot: &protoc.DepthReply{
+			Symbol:        symbol,
+			Bids:          newPriceLevels(md.Snapshot.Bids),
+			Asks:          newPriceLevels(md.Snapshot.Asks),
+			PriceScale:    int32(inst.PriceScale),
+			QuantityScale: int32(inst.QtyScale),
+		}}
+	case orderbook.MarketDataTop:
+		m.Event = &protoc.MarketData_Top{Top: &protoc.TopOfBook{
+			Bid: newPriceLevel(md.Top.Bid),
+			Ask: newPriceLevel(md.Top.Ask),
+		}}
+	case orderbook.MarketDataLevel:
+		m.Event = &protoc.MarketData_Level{Level: &protoc.LevelUpdate{
+			Side:  md.Side.String(),
+			Level: newPriceLevel(md.Level),
+		}}
+	case orderbook.MarketDataTrade:
+		m.Event = &protoc.MarketData_Trade{Trade: newTradePrint(md.Trade)}
+	}
+	return m
+}
+
+// newPriceLevel converts the level of the orderbook to the message
+func newPriceLevel(l orderbook.Level) *protoc.PriceLevel {
+	return &protoc.PriceLevel{Price: int64(l.Price), Quantity: int64(l.Qty), Orders: int32(l.Orders)}
+}
+
+// newPriceLevels converts the levels of the orderbook to the reply
+func newPriceLevels(levels []orderbook.Level) []*protoc.PriceLevel {
+	pls := make([]*protoc.PriceLevel, 0, len(levels))
+	for _, l := range levels {
+		pls = append(pls, newPriceLevel(l))
+	}
+	return pls
+}
+
+// newOrderReply converts the order of the instrument and its status to the reply
+func newOrderReply(symbol string, inst orderbook.Instrument, o *orderbook.Order, ostatus orderbook.OrderStatus) *protoc.OrderReply {
+	return &protoc.OrderReply{
+		Symbol:    symbol,
 		ID:        o.ID.String(),
 		Side:      o.Side.String(),
 		Price:     int64(o.Price),
@@ -129,33 +481,224 @@ func (s *Server) Get(ctx context.Context, order *protoc.GetOrder) (*protoc.Order
 		Status:    ostatus.String(),
 		Quantity:  int64(o.Qty),
 		Timestamp: o.Time.Unix(),
+
+		OriginalQuantity:  int64(o.OriginalQty),
+		FilledQuantity:    int64(o.FilledQty),
+		RemainingQuantity: int64(o.RemainingQty()),
+		AveragePrice:      o.AvgPrice,
+		TimeInForce:       o.TimeInForce.String(),
+		ExpireTime:        expireTime(o),
+		Account:           o.Account,
+		PriceScale:        int32(inst.PriceScale),
+		QuantityScale:     int32(inst.QtyScale),
+		StopPrice:         int64(o.StopPrice),
+		Triggered:         o.Triggered,
+		DisplayQuantity:   int64(o.DisplayQty),
+		HiddenQuantity:    int64(o.HiddenQty),
+		PostOnly:          o.PostOnly.String(),
+		ReduceOnly:        o.ReduceOnly,
+		Stp:               o.STP.String(),
+		ProtectionPrice:   int64(o.ProtectionPrice),
+	}
+}
+
+// expireTime returns the unix timestamp of the expire time of the order, 0 if the order has no expire time
+func expireTime(o *orderbook.Order) int64 {
+	if o.ExpireTime.IsZero() {
+		return 0
+	}
+	return o.ExpireTime.Unix()
+}
+
+// Deposit adds the amount to the available balance of the asset of the account, it is an admin call
+func (s *Server) Deposit(ctx context.Context, req *protoc.BalanceRequest) (*protoc.Balance, error) {
+	if err := admin(ctx); err != nil {
+		return nil, err
+	}
+	scale, amount, err := s.amount(req)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.ledger.Deposit(req.Account, req.Asset, amount)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return newBalance(req.Account, scale, b), nil
+}
+
+// Withdraw subtracts the amount from the available balance of the asset of the account, it is an admin call
+func (s *Server) Withdraw(ctx context.Context, req *protoc.BalanceRequest) (*protoc.Balance, error) {
+	if err := admin(ctx); err != nil {
+		return nil, err
+	}
+	scale, amount, err := s.amount(req)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.ledger.Withdraw(req.Account, req.Asset, amount)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return newBalance(req.Account, scale, b), nil
+}
+
+// GetBalance returns the balance of the asset of the account, or all the balances of the account if the asset
+// is empty
+func (s *Server) GetBalance(ctx context.Context, req *protoc.BalanceRequest) (*protoc.Balances, error) {
+	if s.ledger == nil {
+		return nil, status.Errorf(codes.Unimplemented, "the accounts are disabled")
+	}
+	account, err := ownAccount(ctx, req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := s.ledger.Balances(account)
+	if len(req.Asset) > 0 {
+		if _, err := s.ledger.Scale(req.Asset); err != nil {
+			return nil, statusError(err)
+		}
+		balances = []orderbook.Balance{s.ledger.Balance(account, req.Asset)}
+	}
+	reply := &protoc.Balances{Account: account, Balances: make([]*protoc.Balance, 0, len(balances))}
+	for _, b := range balances {
+		scale, _ := s.ledger.Scale(b.Asset)
+		reply.Balances = append(reply.Balances, newBalance(account, scale, b))
 	}
 	return reply, nil
 }
 
+// ownOrder checks if the client of the call can act on the order
+func ownOrder(ctx context.Context, ob *orderbook.OrderBook, id string) error {
+	var o orderbook.Order
+	if _, err := ob.GetOrder(id, &o); err != nil {
+		return statusError(err)
+	}
+	return authorize(ctx, o.Account)
+}
+
+// amount returns the scale of the asset and the amount of the request in the smallest units of the scale
+func (s *Server) amount(req *protoc.BalanceRequest) (int, int, error) {
+	if s.ledger == nil {
+		return 0, 0, status.Errorf(codes.Unimplemented, "the accounts are disabled")
+	}
+	scale, err := s.ledger.Scale(req.Asset)
+	if err != nil {
+		return 0, 0, statusError(err)
+	}
+	amount := int(req.Amount)
+	if len(req.DecimalAmount) > 0 {
+		if amount, err = orderbook.ParseDecimal(req.DecimalAmount, scale); err != nil {
+			return 0, 0, statusError(err)
+		}
+	}
+	return scale, amount, nil
+}
+
+// newBalance converts the balance of the ledger to the message
+func newBalance(account string, scale int, b orderbook.Balance) *protoc.Balance {
+	return &protoc.Balance{
+		Account:   account,
+		Asset:     b.Asset,
+		Available: int64(b.Available),
+		Held:      int64(b.Held),
+		Scale:     int32(scale),
+	}
+}
+
+// statusError converts the error of the orderbook to the error with gRPC status code
+func statusError(err error) error {
+	var re *orderbook.RiskError
+	if errors.As(err, &re) {
+		return riskStatus(re)
+	}
+	switch {
+	case errors.Is(err, orderbook.ErrDataNotFound),
+		errors.Is(err, orderbook.ErrSymbolNotFound),
+		errors.Is(err, orderbook.ErrAssetNotFound):
+		return status.Errorf(codes.NotFound, err.Error())
+	case errors.Is(err, orderbook.ErrSymbolExists):
+		return status.Errorf(codes.AlreadyExists, err.Error())
+	case errors.Is(err, orderbook.ErrBadOrderPrice),
+		errors.Is(err, orderbook.ErrBadOrderQty),
+		errors.Is(err, orderbook.ErrBadStopPrice),
+		errors.Is(err, orderbook.ErrBadDisplayQty),
+		errors.Is(err, orderbook.ErrBadPostOnly),
+		errors.Is(err, orderbook.ErrPostOnlyMarket),
+		errors.Is(err, orderbook.ErrBadSTP),
+		errors.Is(err, orderbook.ErrBadProtectionPrice),
+		errors.Is(err, orderbook.ErrBadSlippage),
+		errors.Is(err, orderbook.ErrBadAmount),
+		errors.Is(err, orderbook.ErrAccountRequired),
+		errors.Is(err, orderbook.ErrBadTickSize),
+		errors.Is(err, orderbook.ErrBadLotSize),
+		errors.Is(err, orderbook.ErrOrderQtyTooSmall),
+		errors.Is(err, orderbook.ErrOrderQtyTooLarge),
+		errors.Is(err, orderbook.ErrBadDecimal),
+		errors.Is(err, orderbook.ErrBadQueueSize),
+		errors.Is(err, orderbook.ErrBadOrderExpiration),
+		errors.Is(err, orderbook.ErrBadTimeInForce),
+		errors.Is(err, orderbook.ErrBadExpireTime),
+		errors.Is(err, orderbook.ErrBadSymbol):
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	case errors.Is(err, orderbook.ErrTooLargeSizeOfQueue):
+		return status.Errorf(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, orderbook.ErrOrderNotFilled),
+		errors.Is(err, orderbook.ErrTradingHalted),
+		errors.Is(err, orderbook.ErrSnapshotDisabled),
+		errors.Is(err, orderbook.ErrNoLiquidity),
+		errors.Is(err, orderbook.ErrInsufficientFunds),
+		errors.Is(err, orderbook.ErrPostOnlyWouldTrade),
+		errors.Is(err, orderbook.ErrReduceOnly):
+		return status.Errorf(codes.FailedPrecondition, err.Error())
+	}
+	return status.Errorf(codes.Internal, err.Error())
+}
+
+// riskStatus returns the FailedPrecondition status of the rejection of the risk check, the typed reason, limit and
+// value are in its details
+func riskStatus(re *orderbook.RiskError) error {
+	st := status.New(codes.FailedPrecondition, re.Error())
+	detailed, err := st.WithDetails(
+		&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: string(re.Reason), Subject: re.Account, Description: re.Error()},
+		}},
+		&errdetails.ErrorInfo{Reason: string(re.Reason), Domain: "mytrader", Metadata: map[string]string{
+			"account": re.Account,
+			"limit":   strconv.Itoa(re.Limit),
+			"value":   strconv.Itoa(re.Value),
+		}},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
 func (s *Server) Run() error {
 
 	ctx, cancel := context.WithCancel(context.TODO())
-	go s.ob.AutoCleanOrderQueue(ctx)
+	go s.ex.AutoCleanOrderQueue(ctx)
 	defer cancel()
 
-	s.ob.Info()
+	s.ex.Info()
 
 	log.Println("server runs at", s.addr)
-	log.Printf("[Max. size of the queue]: %d\n", orderbook.MaxQueueSize)
-	log.Printf("[Order live time]: %v\n", orderbook.OrderExpiration)
+	log.Printf("[Symbols]: %v\n", s.ex.Symbols())
+	log.Printf("[TLS]: %v, [mTLS]: %v, [API keys]: %d\n", s.tls != nil, s.tls != nil && s.tls.ClientCAs != nil, len(s.credentials))
 
 	lis, err := net.Listen("tcp", s.addr)
 	if err != nil {
 		return err
 	}
 
-	gs := grpc.NewServer()
+	gs := grpc.NewServer(s.serverOptions()...)
 	protoc.RegisterTraderServer(gs, s)
+	protoc.RegisterAdminServer(gs, s.Admin())
 
 	// for gracful shutdown
 	var se serveErr
-	shutdown := make(chan os.Signal)
+	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM, se)
 
 	go func() {
@@ -0,0 +1,338 @@
+package server
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"mytrader.github.com/orderbook"
+	"mytrader.github.com/service/protoc"
+)
+
+// newTestClient starts the server with the exchange and the options on the in-memory listener and returns its client
+func newTestClient(tb testing.TB, ex *orderbook.Exchange, opts ...Option) protoc.TraderClient {
+	tb.Helper()
+	return newTestServer(tb, ex, opts...)()
+}
+
+// testClient is the client of the Trader and Admin services
+type testClient struct {
+	protoc.TraderClient
+	protoc.AdminClient
+}
+
+// newTestServer starts the server with the exchange and the options on the in-memory listener and returns the
+// function which dials the clients of it with the dial options
+func newTestServer(tb testing.TB, ex *orderbook.Exchange, opts ...Option) func(dialOpts ...grpc.DialOption) testClient {
+	tb.Helper()
+	s, err := New(append(opts, WithExchange(ex))...)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer(s.serverOptions()...)
+	protoc.RegisterTraderServer(gs, s)
+	protoc.RegisterAdminServer(gs, s.Admin())
+	go gs.Serve(lis)
+	tb.Cleanup(gs.Stop)
+
+	return func(dialOpts ...grpc.DialOption) testClient {
+		tb.Helper()
+		conn, err := grpc.Dial("bufnet", append([]grpc.DialOption{
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		}, dialOpts...)...)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		tb.Cleanup(func() { conn.Close() })
+		return testClient{protoc.NewTraderClient(conn), protoc.NewAdminClient(conn)}
+	}
+}
+
+// newTestExchange returns the exchange with the symbol and the large queue
+func newTestExchange(tb testing.TB, symbol string) *orderbook.Exchange {
+	tb.Helper()
+	ex := orderbook.NewExchange()
+	if _, err := ex.AddSymbol(symbol, orderbook.WithMaxQueueSize(1<<30)); err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(ex.Close)
+	return ex
+}
+
+// randomOrder returns the limit order around the price 100
+func randomOrder(r *rand.Rand, symbol string) *protoc.Order {
+	return &protoc.Order{
+		Symbol:    symbol,
+		Side:      int32(r.Intn(2)),
+		PriceMode: int32(orderbook.Limit),
+		Price:     int64(95 + r.Intn(10)),
+		Quantity:  int64(1 + r.Intn(20)),
+	}
+}
+
+func TestConcurrentCreate(t *testing.T) {
+	ex := newTestExchange(t, "BTCUSD")
+	client := newTestClient(t, ex)
+
+	const (
+		clients = 8
+		orders  = 50
+	)
+	var wg sync.WaitGroup
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for j := 0; j < orders; j++ {
+				reply, err := client.Create(context.Background(), randomOrder(r, "BTCUSD"))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				// the order can be read right after it is created
+				got, err := client.Get(context.Background(), &protoc.GetOrder{Id: reply.ID, Symbol: "BTCUSD"})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if got.FilledQuantity < reply.FilledQuantity {
+					t.Errorf("the filled quantity of the order[%s] should not be less than %d, but got %d",
+						reply.ID, reply.FilledQuantity, got.FilledQuantity)
+					return
+				}
+				if r.Intn(4) == 0 {
+					client.Cancel(context.Background(), &protoc.CancelOrder{Id: reply.ID, Symbol: "BTCUSD"})
+				}
+				client.GetDepth(context.Background(), &protoc.DepthRequest{Symbol: "BTCUSD", Levels: 5})
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+
+	depth, err := client.GetDepth(context.Background(), &protoc.DepthRequest{Symbol: "BTCUSD", Levels: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(depth.Bids) > 0 && len(depth.Asks) > 0 && depth.Bids[0].Price >= depth.Asks[0].Price {
+		t.Fatal("the book is crossed", depth)
+	}
+}
+
+// BenchmarkCreateParallel measures the throughput of the Create RPC with concurrent clients
+func BenchmarkCreateParallel(b *testing.B) {
+	ex := newTestExchange(b, "BTCUSD")
+	client := newTestClient(b, ex)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			if _, err := client.Create(context.Background(), randomOrder(r, "BTCUSD")); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+// BenchmarkGetParallel measures the throughput of the Get RPC while the orders are created
+func BenchmarkGetParallel(b *testing.B) {
+	ex := newTestExchange(b, "BTCUSD")
+	client := newTestClient(b, ex)
+
+	reply, err := client.Create(context.Background(), &protoc.Order{
+		Symbol: "BTCUSD", Side: int32(orderbook.Buy), PriceMode: int32(orderbook.Limit), Price: 1, Quantity: 10,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		r := rand.New(rand.NewSource(1))
+		for ctx.Err() == nil {
+			client.Create(ctx, randomOrder(r, "BTCUSD"))
+		}
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := client.Get(context.Background(), &protoc.GetOrder{Id: reply.ID, Symbol: "BTCUSD"}); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+// BenchmarkCreateDeepBook measures the Create RPC with a deep book, Create reads the order after it is processed,
+// so the read should not copy the book
+func BenchmarkCreateDeepBook(b *testing.B) {
+	ex := newTestExchange(b, "BTCUSD")
+	ob, err := ex.OrderBook("BTCUSD")
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < 20000; i++ {
+		side, price := orderbook.Buy, 1000-i%500
+		if i%2 == 1 {
+			side, price = orderbook.Sell, 1100+i%500
+		}
+		if _, err := ob.ProcessLimitOrder(side, price, 10); err != nil {
+			b.Fatal(err)
+		}
+	}
+	client := newTestClient(b, ex)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		o := &protoc.Order{Symbol: "BTCUSD", Side: int32(orderbook.Buy), PriceMode: int32(orderbook.Limit), Price: int64(1000 - i%500), Quantity: 1}
+		if _, err := client.Create(context.Background(), o); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestDecimalOrder(t *testing.T) {
+	ex := orderbook.NewExchange()
+	inst := orderbook.Instrument{PriceScale: 2, QtyScale: 3, TickSize: 25, LotSize: 1, MinQty: 1}
+	if _, err := ex.AddSymbol("BTCUSD", orderbook.WithInstrumentSpec(inst)); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(ex.Close)
+	client := newTestClient(t, ex)
+
+	reply, err := client.Create(context.Background(), &protoc.Order{
+		Symbol: "BTCUSD", Side: int32(orderbook.Buy), PriceMode: int32(orderbook.Limit), DecimalPrice: "101.25", DecimalQuantity: "0.5",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Price != 10125 || reply.Quantity != 500 || reply.PriceScale != 2 || reply.QuantityScale != 3 {
+		t.Fatal("the order should be 0.500@101.25", reply)
+	}
+
+	for _, o := range []*protoc.Order{
+		{DecimalPrice: "101.3", DecimalQuantity: "1"},
+		{DecimalPrice: "101.25", DecimalQuantity: "0.0001"},
+		{DecimalPrice: "abc", DecimalQuantity: "1"},
+	} {
+		o.Symbol, o.Side, o.PriceMode = "BTCUSD", int32(orderbook.Sell), int32(orderbook.Limit)
+		if _, err := client.Create(context.Background(), o); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("the order %s@%s should be rejected as invalid argument, but got %v", o.DecimalQuantity, o.DecimalPrice, err)
+		}
+	}
+}
+
+func TestBalance(t *testing.T) {
+	ledger, err := orderbook.NewLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex := orderbook.NewExchange()
+	inst := orderbook.Instrument{PriceScale: 2, QtyScale: 3, TickSize: 1, LotSize: 1, MinQty: 1, Base: "BTC", Quote: "USD"}
+	if _, err := ex.AddSymbol("BTCUSD", orderbook.WithInstrumentSpec(inst), orderbook.WithLedger(ledger)); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(ex.Close)
+	dial := newTestServer(t, ex, WithLedger(ledger), WithCredentials(
+		Credential{Key: "alice-key", Account: "alice", Role: RoleTrader},
+		Credential{Key: "admin-key", Role: RoleAdmin},
+	))
+	client, adminClient := dial(AuthDialOptions("alice-key", "")...), dial(AuthDialOptions("admin-key", "")...)
+	ctx := context.Background()
+
+	// deposit is an admin call, the quote asset is in the scale of price * qty
+	deposit := &protoc.BalanceRequest{Account: "alice", Asset: "USD", DecimalAmount: "100"}
+	if _, err := client.Deposit(ctx, deposit); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("the deposit of the trader should be denied", err)
+	}
+	b, err := adminClient.Deposit(ctx, deposit)
+	if err != nil || b.Available != 10000000 || b.Scale != 5 {
+		t.Fatal("the deposit should be 100.00000 USD", b, err)
+	}
+	if _, err := adminClient.Deposit(ctx, &protoc.BalanceRequest{Asset: "USD", Amount: 1}); status.Code(err) != codes.InvalidArgument {
+		t.Fatal("the deposit should require the account", err)
+	}
+	if _, err := adminClient.Deposit(ctx, &protoc.BalanceRequest{Account: "alice", Asset: "ETH", Amount: 1}); status.Code(err) != codes.NotFound {
+		t.Fatal("the unknown asset should not be found", err)
+	}
+
+	order := &protoc.Order{
+		Symbol: "BTCUSD", Account: "alice", Side: int32(orderbook.Buy), PriceMode: int32(orderbook.Limit),
+		DecimalPrice: "50", DecimalQuantity: "3",
+	}
+	if _, err := client.Create(ctx, order); status.Code(err) != codes.FailedPrecondition {
+		t.Fatal("the order should be rejected for insufficient funds", err)
+	}
+	order.DecimalQuantity = "1.5"
+	if _, err := client.Create(ctx, order); err != nil {
+		t.Fatal(err)
+	}
+	balances, err := client.GetBalance(ctx, &protoc.BalanceRequest{Account: "alice"})
+	if err != nil || len(balances.Balances) != 1 || balances.Balances[0].Available != 2500000 || balances.Balances[0].Held != 7500000 {
+		t.Fatal("75 USD should be held", balances, err)
+	}
+
+	// withdraw is an admin call
+	req := &protoc.BalanceRequest{Account: "alice", Asset: "USD", DecimalAmount: "25"}
+	if _, err := client.Withdraw(ctx, req); status.Code(err) != codes.PermissionDenied {
+		t.Fatal("the withdraw of the trader should be denied", err)
+	}
+	if b, err := adminClient.Withdraw(ctx, req); err != nil || b.Available != 0 || b.Held != 7500000 {
+		t.Fatal("the available USD should be withdrawn", b, err)
+	}
+	if _, err := adminClient.Withdraw(ctx, req); status.Code(err) != codes.FailedPrecondition {
+		t.Fatal("the held USD can not be withdrawn", err)
+	}
+}
+
+func TestRiskRejection(t *testing.T) {
+	ex := orderbook.NewExchange()
+	cfg := orderbook.RiskConfig{Limits: orderbook.RiskLimits{MaxQty: 10}}
+	if _, err := ex.AddSymbol("BTCUSD", orderbook.WithRiskConfig(cfg)); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(ex.Close)
+	client := newTestClient(t, ex)
+
+	order := &protoc.Order{Symbol: "BTCUSD", Account: "alice", Side: int32(orderbook.Buy), PriceMode: int32(orderbook.Limit), Price: 100, Quantity: 11}
+	_, err := client.Create(context.Background(), order)
+	st := status.Convert(err)
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatal("the order should be rejected by the risk check", err)
+	}
+	var violation *errdetails.PreconditionFailure_Violation
+	var info *errdetails.ErrorInfo
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.PreconditionFailure:
+			violation = d.Violations[0]
+		case *errdetails.ErrorInfo:
+			info = d
+		}
+	}
+	if violation == nil || violation.Type != string(orderbook.RiskMaxQty) || violation.Subject != "alice" {
+		t.Fatal("the reason should be in the details", st.Details())
+	}
+	if info == nil || info.Metadata["limit"] != "10" || info.Metadata["value"] != "11" {
+		t.Fatal("the limit and value should be in the details", st.Details())
+	}
+}